@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/rsa"
 	"errors"
 	"fmt"
 	"github.com/julienschmidt/httprouter"
@@ -9,15 +10,23 @@ import (
 	"net"
 	"net/http"
 	_ "operation-service/docs"
+	"operation-service/internal/auth"
 	"operation-service/internal/config"
 	"operation-service/internal/controller/http"
 	"operation-service/internal/domain/service"
+	"operation-service/internal/publisher"
 	"operation-service/internal/storage/postgres"
+	"operation-service/migrations"
+	"operation-service/pkg/jwt"
 	"operation-service/pkg/logging"
 	"operation-service/pkg/metric"
+	"operation-service/pkg/migrate"
 	"operation-service/pkg/postgresql"
+	"operation-service/pkg/requestctx"
 	"operation-service/pkg/shutdown"
+	"operation-service/pkg/webhook"
 	"os"
+	"strings"
 	"syscall"
 	"time"
 )
@@ -33,6 +42,11 @@ import (
 
 // @Host 		localhost:10002
 // @BasePath 	/api
+
+// @SecurityDefinitions.apikey	BearerAuth
+// @In							header
+// @Name						Authorization
+// @Description					JWT as "Bearer <token>", the user uuid is taken from the sub claim
 func main() {
 	logging.InitLogger()
 	logger := logging.GetLogger()
@@ -41,6 +55,30 @@ func main() {
 	logger.Info("config initializing")
 	cfg := config.GetConfig()
 
+	logger.Info("storage initializing")
+	postgresPool, err := postgresql.NewClient(context.Background(), 5, *cfg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	migrator, err := migrate.NewMigrator(postgresPool, migrations.FS, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = runMigrateCommand(context.Background(), migrator, os.Args[2:]); err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
+	if cfg.Migrations.Auto {
+		logger.Info("migrations applying")
+		if err = migrator.Up(context.Background()); err != nil {
+			logger.Fatal(err)
+		}
+	}
+	postgresClient := postgresql.NewTxClient(postgresPool)
+
 	logger.Info("router initializing")
 	router := httprouter.New()
 
@@ -51,24 +89,138 @@ func main() {
 	metricHandler := metric.Handler{Logger: logger}
 	metricHandler.Register(router)
 
-	logger.Info("storage initializing")
-	postgresClient, err := postgresql.NewClient(context.Background(), 5, *cfg)
-	if err != nil {
-		logger.Fatal(err)
-	}
+	outboxStorage := postgres.NewOutboxRepo(postgresClient, logger)
+	outboxRelay := service.NewOutboxRelay(outboxStorage, newEventPublisher(cfg, logger), cfg.Outbox.Interval,
+		cfg.Outbox.ClaimTimeout, cfg.Outbox.BatchSize, logger)
+	go outboxRelay.Run(context.Background())
+
+	auditStorage := postgres.NewAuditRepo(postgresClient, logger)
 
 	categoryStorage := postgres.NewCategoryRepo(postgresClient, logger)
-	categoryService := service.NewCategoryService(categoryStorage, logger)
+	categoryService := service.NewCategoryService(categoryStorage, outboxStorage, auditStorage, postgresClient,
+		logger)
 	categoryHandler := controller.NewCategoryHandler(categoryService, logger)
 	categoryHandler.Register(router)
 
+	exchangeRateStorage := postgres.NewExchangeRateRepo(postgresClient, logger)
+	exchangeRateService := service.NewExchangeRateService(exchangeRateStorage, logger)
+	exchangeRateHandler := controller.NewExchangeRateHandler(exchangeRateService, logger)
+	exchangeRateHandler.Register(router)
+
+	accountStorage := postgres.NewAccountRepo(postgresClient, logger)
+	accountService := service.NewAccountService(accountStorage, logger)
+	accountHandler := controller.NewAccountHandler(accountService, logger)
+	accountHandler.Register(router)
+
 	operationStorage := postgres.NewOperationRepo(postgresClient, logger)
-	operationService := service.NewOperationService(operationStorage, categoryStorage, logger)
+
+	budgetStorage := postgres.NewBudgetRepo(postgresClient, logger)
+	budgetService := service.NewBudgetService(budgetStorage, categoryStorage, operationStorage, exchangeRateStorage,
+		logger)
+	budgetHandler := controller.NewBudgetHandler(budgetService, logger)
+	budgetHandler.Register(router)
+
+	webhookStorage := postgres.NewWebhookRepo(postgresClient, logger)
+	webhookSender := webhook.NewPublicSender(cfg.Webhook.Timeout)
+	webhookService := service.NewWebhookService(webhookStorage, webhookSender, logger)
+	webhookHandler := controller.NewWebhookHandler(webhookService, logger)
+	webhookHandler.Register(router)
+
+	webhookDispatcher := service.NewWebhookDispatcher(webhookStorage, webhookSender,
+		postgresClient, cfg.Webhook.Interval, cfg.Webhook.MaxAttempts, logger)
+	go webhookDispatcher.Run(context.Background())
+
+	budgetAlerter := service.NewBudgetAlerter(budgetStorage, webhookStorage, operationStorage, exchangeRateStorage,
+		postgresClient, logger)
+	operationService := service.NewOperationService(operationStorage, categoryStorage, accountStorage, outboxStorage,
+		auditStorage, exchangeRateStorage, budgetAlerter, postgresClient, logger)
 	operationHandler := controller.NewOperationHandler(operationService, logger)
 	operationHandler.Register(router)
 
+	transferStorage := postgres.NewTransferRepo(postgresClient, logger)
+	transferService := service.NewTransferService(transferStorage, operationStorage, accountStorage,
+		outboxStorage, auditStorage, postgresClient, logger)
+	transferHandler := controller.NewTransferHandler(transferService, logger)
+	transferHandler.Register(router)
+
+	recurringOperationStorage := postgres.NewRecurringOperationRepo(postgresClient, logger)
+	recurringOperationService := service.NewRecurringOperationService(recurringOperationStorage, categoryStorage,
+		accountStorage, logger)
+	recurringOperationHandler := controller.NewRecurringOperationHandler(recurringOperationService, logger)
+	recurringOperationHandler.Register(router)
+
+	recurringScheduler := service.NewRecurringScheduler(recurringOperationStorage, operationService, postgresClient,
+		cfg.Scheduler.Interval, logger)
+	go recurringScheduler.Run(context.Background())
+
+	purger := service.NewPurger(operationStorage, transferStorage, categoryStorage, postgresClient,
+		cfg.Purge.Retention, cfg.Purge.Interval, logger)
+	go purger.Run(context.Background())
+
+	reportService := service.NewReportService(operationStorage, categoryStorage, exchangeRateStorage, logger)
+	reportHandler := controller.NewReportHandler(reportService, logger)
+	reportHandler.Register(router)
+
 	logger.Info("start application")
-	start(router, logger, cfg)
+	authenticator := auth.NewAuthenticator(newJWTVerifier(cfg, logger), cfg.Auth.UserClaim,
+		cfg.Auth.RolesClaim, cfg.Auth.AdminRole, []string{metric.URL, "/swagger"}, logger)
+
+	start(requestctx.Middleware(authenticator.Middleware(router)), logger, cfg)
+}
+
+func newJWTVerifier(cfg *config.Config, logger *logging.Logger) *jwt.Verifier {
+	if cfg.Auth.Secret == "" && cfg.Auth.JWKSFile == "" {
+		logger.Fatal("auth secret or jwks file must be set")
+	}
+	if cfg.Auth.Secret != "" && (len(cfg.Auth.Secret) < minAuthSecretLength || isDefaultSecret(cfg.Auth.Secret)) {
+		logger.Fatalf("auth secret must be a random string of at least %d characters", minAuthSecretLength)
+	}
+
+	var keys map[string]*rsa.PublicKey
+	if cfg.Auth.JWKSFile != "" {
+		var err error
+		if keys, err = jwt.LoadJWKS(cfg.Auth.JWKSFile); err != nil {
+			logger.Fatalf("failed to load jwks: %v", err)
+		}
+	}
+	return jwt.NewVerifier(cfg.Auth.Secret, keys, cfg.Auth.Issuer, cfg.Auth.Audience)
+}
+
+// minAuthSecretLength is the length of a HS256 key of full strength, 256 bits.
+const minAuthSecretLength = 32
+
+// defaultSecrets are secrets once committed to the repository or found in examples,
+// tokens signed with them can be forged by anyone.
+var defaultSecrets = []string{"local-development-secret", "secret", "changeme", "your-256-bit-secret"}
+
+func isDefaultSecret(secret string) bool {
+	for _, s := range defaultSecrets {
+		if strings.EqualFold(secret, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryPublisherCapacity is the number of the latest events kept by the memory publisher.
+const memoryPublisherCapacity = 1000
+
+func newEventPublisher(cfg *config.Config, logger *logging.Logger) service.EventPublisher {
+	switch cfg.Outbox.Publisher {
+	case "memory":
+		logger.Warnf("outbox events are not delivered, only the latest %d are kept in memory",
+			memoryPublisherCapacity)
+		return publisher.NewMemoryPublisher(memoryPublisherCapacity)
+	case "webhook":
+		if cfg.Outbox.URL == "" {
+			logger.Fatal("outbox url must be set for webhook publisher")
+		}
+		return publisher.NewWebhookPublisher(webhook.NewSender(cfg.Webhook.Timeout), cfg.Outbox.URL,
+			cfg.Outbox.Secret)
+	default:
+		logger.Fatalf("unknown outbox publisher %q", cfg.Outbox.Publisher)
+		return nil
+	}
 }
 
 func start(router http.Handler, logger *logging.Logger, cfg *config.Config) {
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"operation-service/pkg/migrate"
+	"strconv"
+	"time"
+)
+
+const migrateUsage = `usage: app migrate [command]
+
+commands:
+  up                 apply all pending migrations (default)
+  down [steps]       revert the given number of the latest migrations (default 1)
+  status             list migrations and the time they were applied at
+  baseline VERSION   record migrations up to VERSION as applied without running them`
+
+// runMigrateCommand runs the migrate subcommand of the service binary.
+func runMigrateCommand(ctx context.Context, migrator *migrate.Migrator, args []string) error {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return errors.New("steps must be a positive integer")
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%03d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	case "baseline":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errors.New("version must be an integer")
+		}
+		return migrator.Baseline(ctx, version)
+	default:
+		return errors.New(migrateUsage)
+	}
+}
@@ -21,24 +21,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/categories": {
+        "/accounts": {
             "post": {
-                "description": "Creates new category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates new account (card, cash wallet, etc.)",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Account"
                 ],
-                "summary": "Create category",
+                "summary": "Create account",
                 "parameters": [
                     {
-                        "description": "Category data",
+                        "description": "Account data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateCategoryDTO"
+                            "$ref": "#/definitions/dto.CreateAccountDTO"
                         }
                     }
                 ],
@@ -52,8 +57,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -67,17 +72,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/one": {
+        "/accounts/one": {
             "delete": {
-                "description": "Delete category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete account. Account with operations or used by recurring operations can not be deleted",
                 "tags": [
-                    "Category"
+                    "Account"
                 ],
-                "summary": "Delete category",
+                "summary": "Delete account",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category's uuid",
+                        "description": "Account's uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -87,14 +97,20 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
-                    "404": {
-                        "description": "Category is not found",
+                    "400": {
+                        "description": "Account has operations",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "404": {
+                        "description": "Account is not found",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -108,29 +124,34 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Update category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update account",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Account"
                 ],
-                "summary": "Update category",
+                "summary": "Update account",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category's uuid",
+                        "description": "Account's uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Category's data",
+                        "description": "Account's data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateCategoryDTO"
+                            "$ref": "#/definitions/dto.UpdateAccountDTO"
                         }
                     }
                 ],
@@ -144,8 +165,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -159,20 +180,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/one/": {
+        "/accounts/one/": {
             "get": {
-                "description": "Get category by uuid",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get account by uuid",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Account"
                 ],
-                "summary": "Get category by uuid",
+                "summary": "Get account by uuid",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Category's uuid",
+                        "description": "Account's uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -180,19 +206,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category",
+                        "description": "Account",
                         "schema": {
-                            "$ref": "#/definitions/entity.Category"
+                            "$ref": "#/definitions/entity.Account"
                         }
                     },
-                    "404": {
-                        "description": "Category not found",
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "404": {
+                        "description": "Account not found",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -206,43 +238,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/user_uuid/": {
+        "/accounts/one/{uuid}/balance": {
             "get": {
-                "description": "Get list of categories belonging to user",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get sum of all operations of the account in account's currency",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Category"
+                    "Account"
                 ],
-                "summary": "Get categories by user's uuid",
+                "summary": "Get account balance",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User's uuid",
-                        "name": "user_uuid",
+                        "description": "Account's uuid",
+                        "name": "uuid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Categories",
+                        "description": "Account balance",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entity.Category"
-                            }
+                            "$ref": "#/definitions/entity.AccountBalance"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "404": {
+                        "description": "Account not found",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -256,38 +296,93 @@ const docTemplate = `{
                 }
             }
         },
-        "/metric": {
+        "/accounts/user_uuid/": {
             "get": {
-                "description": "Checks that the server is up and running",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of accounts belonging to user",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Heartbeat"
+                    "Account"
+                ],
+                "summary": "Get accounts by user's uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Heartbeat",
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "Page of accounts",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_Account"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
                     }
                 }
             }
         },
-        "/operations": {
+        "/budgets": {
             "post": {
-                "description": "Creates new operation",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates limit of expenses of expense category per week, month or year.\nCurrency defaults to category's one. Category can have one budget per period",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Operation"
+                    "Budget"
                 ],
-                "summary": "Create operation",
+                "summary": "Create budget",
                 "parameters": [
                     {
-                        "description": "Operation's data",
+                        "description": "Budget data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateOperationDTO"
+                            "$ref": "#/definitions/dto.CreateBudgetDTO"
                         }
                     }
                 ],
@@ -301,8 +396,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -316,17 +417,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/operations/one": {
+        "/budgets/one": {
             "delete": {
-                "description": "Delete operation",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete budget",
                 "tags": [
-                    "Operation"
+                    "Budget"
                 ],
-                "summary": "Delete operation",
+                "summary": "Delete budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operation's uuid",
+                        "description": "Budget's uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -336,14 +442,14 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
-                    "404": {
-                        "description": "Operation is not found",
+                    "400": {
+                        "description": "Validation error",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -357,29 +463,34 @@ const docTemplate = `{
                 }
             },
             "patch": {
-                "description": "Update Operation",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update period, limit or rollover of budget",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Operation"
+                    "Budget"
                 ],
-                "summary": "Update Operation",
+                "summary": "Update budget",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operation's uuid",
+                        "description": "Budget's uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Operation's data",
+                        "description": "Budget's data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateOperationDTO"
+                            "$ref": "#/definitions/dto.UpdateBudgetDTO"
                         }
                     }
                 ],
@@ -393,8 +504,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -408,20 +519,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/operations/one/": {
+        "/budgets/one/": {
             "get": {
-                "description": "Get operation by uuid",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get budget by uuid",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Operation"
+                    "Budget"
                 ],
-                "summary": "Get operation by uuid",
+                "summary": "Get budget by uuid",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Operation's uuid",
+                        "description": "Budget's uuid",
                         "name": "uuid",
                         "in": "path",
                         "required": true
@@ -429,19 +545,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Operation",
+                        "description": "Budget",
                         "schema": {
-                            "$ref": "#/definitions/entity.Operation"
+                            "$ref": "#/definitions/entity.Budget"
                         }
                     },
-                    "404": {
-                        "description": "Operation not found",
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
                     },
-                    "418": {
-                        "description": "Something wrong with application logic",
+                    "404": {
+                        "description": "Budget not found",
                         "schema": {
                             "$ref": "#/definitions/apperror.AppError"
                         }
@@ -454,85 +576,2830 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "apperror.AppError": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "developer_message": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreateCategoryDTO": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "type": {
-                    "$ref": "#/definitions/types.CategoryType"
-                },
-                "user_uuid": {
-                    "type": "string"
-                }
-            }
         },
-        "dto.CreateOperationDTO": {
-            "type": "object",
-            "properties": {
-                "category_uuid": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "money_sum": {
-                    "type": "number"
+        "/budgets/one/{uuid}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get spent, limit and remaining sum of the budget in the current period.\nRemaining sum includes rolled over sum left or overspent in the previous period\nif the budget has rollover. Expenses in other currencies are converted at the rate of their day",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Get budget status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Budget status",
+                        "schema": {
+                            "$ref": "#/definitions/entity.BudgetStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Exchange rate is unknown",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Budget not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
                 }
             }
         },
-        "dto.UpdateCategoryDTO": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
+        "/budgets/user_uuid/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of budgets belonging to user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Budget"
+                ],
+                "summary": "Get budgets by user's uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of budgets",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_Budget"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates new category. Parent category must belong to the same user and have the same type",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Create category",
+                "parameters": [
+                    {
+                        "description": "Category data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateCategoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Category with the same name and type already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/one": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete category. Category with subcategories can not be deleted. Category with operations\nis deleted only if operations are reassigned to another category of the same user and type\nor deleted with cascade, in the same transaction as the category. Deleted category can be\nrestored until it is purged after the retention period",
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Delete category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Category's uuid to move operations to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete operations of the category",
+                        "name": "cascade",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Category has subcategories or operations",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Category is not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update category. Category can be moved under another category of the same type\nwhich is not its subcategory, or made a root one with empty parent uuid",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Update category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category's data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateCategoryDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Category with the same name and type already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/one/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get category by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get category by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Category not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/one/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore deleted category together with operations deleted with it by cascade.\nSubcategory can be restored only if its parent is not deleted",
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Restore category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Category's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Parent category is deleted",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted category is not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "409": {
+                        "description": "Category with the same name and type already exists",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/user_uuid/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of categories belonging to user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get categories by user's uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of categories",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_Category"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not the authenticated one",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/user_uuid/{user_uuid}/tree": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all categories of user arranged into trees of subcategories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Category"
+                ],
+                "summary": "Get category tree by user's uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Root categories with subcategories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.CategoryNode"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the latest known exchange rate on the date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange rate"
+                ],
+                "summary": "Get exchange rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base currency",
+                        "name": "base",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Quote currency",
+                        "name": "quote",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date time (RFC 3339), now by default",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exchange rate",
+                        "schema": {
+                            "$ref": "#/definitions/entity.ExchangeRate"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or replaces exchange rates, requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchange rate"
+                ],
+                "summary": "Save exchange rates",
+                "parameters": [
+                    {
+                        "description": "Exchange rates",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CreateExchangeRateDTO"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or replaces exchange rates from CSV with columns base,quote,date,rate. Header row is optional. Requires admin role",
+                "consumes": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Exchange rate"
+                ],
+                "summary": "Import exchange rates",
+                "parameters": [
+                    {
+                        "description": "CSV with exchange rates",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/metric": {
+            "get": {
+                "description": "Checks that the server is up and running",
+                "tags": [
+                    "Heartbeat"
+                ],
+                "summary": "Heartbeat",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/operations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of operations matching filters. Only operations of the authenticated user are returned",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Get operations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid, the authenticated user by default",
+                        "name": "user_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Category's uuid",
+                        "name": "category_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account's uuid",
+                        "name": "account_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound of operation date (RFC 3339)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound of operation date (RFC 3339)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Minimal absolute money sum",
+                        "name": "min_sum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximal absolute money sum",
+                        "name": "max_sum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency code",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Substring of description",
+                        "name": "description",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of operations",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_Operation"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates new operation",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Create operation",
+                "parameters": [
+                    {
+                        "description": "Operation's data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOperationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get total income, total expense and net balance of user's operations per currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Get balance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid, the authenticated user by default",
+                        "name": "user_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound of operation date (RFC 3339)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound of operation date (RFC 3339)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to convert all sums to at the rates of operation dates",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Balance per currency",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Balance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/one": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete operation. Deleted operation can be restored until it is purged after the retention period",
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Delete operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Operation is not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update Operation",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Update Operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Operation's data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateOperationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/one/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get operation by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Get operation by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operation",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Operation"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Operation not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/one/{uuid}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get changes of operation starting from the latest one, with the actor and the request id of\neach change. History of deleted operations is kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Get operation history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of changes",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_AuditRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/operations/one/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore deleted operation. Operation of a deleted category can be restored only with the category,\noperation of a transfer only with the transfer",
+                "tags": [
+                    "Operation"
+                ],
+                "summary": "Restore operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Category of the operation is deleted",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted operation is not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-operations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates template of operation repeated every interval of days, weeks, months or years\nfrom start date until end date or count of occurrences. Operations are created by scheduler\nwhen they are due, including ones between past start date and now",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring operation"
+                ],
+                "summary": "Create recurring operation",
+                "parameters": [
+                    {
+                        "description": "Recurring operation data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRecurringOperationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Category or account not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-operations/one": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete recurring operation. Operations already created by it are kept",
+                "tags": [
+                    "Recurring operation"
+                ],
+                "summary": "Delete recurring operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-operations/one/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get recurring operation by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring operation"
+                ],
+                "summary": "Get recurring operation by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recurring operation",
+                        "schema": {
+                            "$ref": "#/definitions/entity.RecurringOperation"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recurring operation not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-operations/one/{uuid}/pause": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stops creating operations of recurring operation until it is resumed",
+                "tags": [
+                    "Recurring operation"
+                ],
+                "summary": "Pause recurring operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recurring operation not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-operations/one/{uuid}/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Resumes paused recurring operation. Occurrences missed during the pause are skipped",
+                "tags": [
+                    "Recurring operation"
+                ],
+                "summary": "Resume recurring operation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recurring operation's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recurring operation not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurring-operations/user_uuid/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of recurring operations belonging to user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Recurring operation"
+                ],
+                "summary": "Get recurring operations by user's uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of recurring operations",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_RecurringOperation"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/by-category": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get income and expense sums of user's operations grouped by category, time bucket and currency",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Get report by category",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid, the authenticated user by default",
+                        "name": "user_uuid",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Lower bound of operation date (RFC 3339)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upper bound of operation date (RFC 3339)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Time bucket",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency to convert all sums to at the rates of operation dates",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add sums of subcategories to their parent categories",
+                        "name": "rollup",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.CategoryReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves money between two accounts of the same user and currency.\nTransfer is stored with a debit operation on the source account and a credit operation\non the target account, which are not counted in balance and reports",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Create transfer",
+                "parameters": [
+                    {
+                        "description": "Transfer data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTransferDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Account not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/one": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete transfer together with its debit and credit operations. Deleted transfer can be restored\nuntil it is purged after the retention period",
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Delete transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update transfer together with its debit and credit operations",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Update transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer's data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateTransferDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/one/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get transfer by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Get transfer by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Transfer not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/one/{uuid}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore deleted transfer together with its debit and credit operations",
+                "tags": [
+                    "Transfer"
+                ],
+                "summary": "Restore transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transfer's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Deleted transfer is not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribes url to user's events. Events are posted as JSON with X-Webhook-Event,\nX-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature headers. The signature is\n\"sha256=\" followed by hex of HMAC-SHA256 of \"\u003ctimestamp\u003e.\u003cbody\u003e\" with the secret.\nFailed deliveries are retried with exponential backoff",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/one": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete webhook together with its deliveries",
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/one/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get webhook by uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get webhook by uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/one/{uuid}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get log of events sent or to be sent to webhook, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook's uuid",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/user_uuid/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of webhooks belonging to user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "Get webhooks by user's uuid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's uuid",
+                        "name": "user_uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-500, default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of webhooks",
+                        "schema": {
+                            "$ref": "#/definitions/operation-service_pkg_pagination.Page-entity_Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "401": {
+                        "description": "User is not authenticated",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/apperror.AppError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "apperror.AppError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/apperror.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "apperror.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateAccountDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "description": "UserUUID is the authenticated user's uuid if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateBudgetDTO": {
+            "type": "object",
+            "properties": {
+                "category_uuid": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "limit": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "period": {
+                    "enum": [
+                        "week",
+                        "month",
+                        "year"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.BudgetPeriod"
+                        }
+                    ]
+                },
+                "rollover": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.CreateCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.CategoryType"
+                },
+                "user_uuid": {
+                    "description": "UserUUID is the authenticated user's uuid if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateExchangeRateDTO": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2024-05-01"
+                },
+                "quote": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "1.0845"
+                }
+            }
+        },
+        "dto.CreateOperationDTO": {
+            "type": "object",
+            "properties": {
+                "account_uuid": {
+                    "type": "string"
+                },
+                "category_uuid": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "12.30"
+                }
+            }
+        },
+        "dto.CreateRecurringOperationDTO": {
+            "type": "object",
+            "properties": {
+                "account_uuid": {
+                    "type": "string"
+                },
+                "category_uuid": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.Frequency"
+                        }
+                    ]
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateTransferDTO": {
+            "type": "object",
+            "properties": {
+                "date_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "from_account_uuid": {
+                    "type": "string"
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "to_account_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/finances"
+                },
+                "user_uuid": {
+                    "description": "UserUUID is the authenticated user's uuid if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateAccountDTO": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateBudgetDTO": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "period": {
+                    "enum": [
+                        "week",
+                        "month",
+                        "year"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.BudgetPeriod"
+                        }
+                    ]
+                },
+                "rollover": {
+                    "type": "boolean"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "description": "ParentUUID moves the category under another one, empty string makes it a root category",
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateOperationDTO": {
+            "type": "object",
+            "properties": {
+                "account_uuid": {
+                    "type": "string"
+                },
+                "category_uuid": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateTransferDTO": {
+            "type": "object",
+            "properties": {
+                "date_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "from_account_uuid": {
+                    "type": "string"
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "to_account_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Account": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.AccountBalance": {
+            "type": "object",
+            "properties": {
+                "account_uuid": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string",
+                    "example": "1250.00"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "entity.AuditRecord": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/entity.FieldChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "entity_uuid": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Balance": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "expense": {
+                    "type": "string",
+                    "example": "40.50"
+                },
+                "income": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "net": {
+                    "type": "string",
+                    "example": "59.50"
+                }
+            }
+        },
+        "entity.BucketSum": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "expense": {
+                    "type": "string",
+                    "example": "40.50"
+                },
+                "income": {
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Budget": {
+            "type": "object",
+            "properties": {
+                "category_uuid": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "limit": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "period": {
+                    "enum": [
+                        "week",
+                        "month",
+                        "year"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.BudgetPeriod"
+                        }
+                    ]
+                },
+                "rollover": {
+                    "type": "boolean"
+                },
+                "user_uuid": {
+                    "type": "string"
                 },
                 "uuid": {
                     "type": "string"
                 }
             }
         },
-        "dto.UpdateOperationDTO": {
+        "entity.BudgetStatus": {
             "type": "object",
             "properties": {
-                "category_uuid": {
+                "budget_uuid": {
                     "type": "string"
                 },
-                "description": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "limit": {
+                    "type": "string",
+                    "example": "500.00"
+                },
+                "overspent": {
+                    "type": "boolean"
+                },
+                "period_end": {
                     "type": "string"
                 },
-                "money_sum": {
-                    "type": "number"
+                "period_start": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "string",
+                    "example": "199.50"
+                },
+                "rolled_over": {
+                    "type": "string",
+                    "example": "20.00"
+                },
+                "spent": {
+                    "type": "string",
+                    "example": "320.50"
+                }
+            }
+        },
+        "entity.Category": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/types.CategoryType"
+                },
+                "user_uuid": {
+                    "type": "string"
                 },
                 "uuid": {
                     "type": "string"
                 }
             }
         },
-        "entity.Category": {
+        "entity.CategoryNode": {
             "type": "object",
             "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.CategoryNode"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
                 "name": {
                     "type": "string"
                 },
+                "parent_uuid": {
+                    "type": "string"
+                },
                 "type": {
                     "$ref": "#/definitions/types.CategoryType"
                 },
@@ -544,26 +3411,381 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.CategoryReport": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.BucketSum"
+                    }
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "category_type": {
+                    "$ref": "#/definitions/types.CategoryType"
+                },
+                "category_uuid": {
+                    "type": "string"
+                },
+                "parent_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "1.0845"
+                }
+            }
+        },
+        "entity.FieldChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                }
+            }
+        },
         "entity.Operation": {
             "type": "object",
             "properties": {
+                "account_uuid": {
+                    "type": "string"
+                },
+                "category_uuid": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "-12.30"
+                },
+                "transfer_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.RecurringOperation": {
+            "type": "object",
+            "properties": {
+                "account_uuid": {
+                    "type": "string"
+                },
                 "category_uuid": {
                     "type": "string"
                 },
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "frequency": {
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.Frequency"
+                        }
+                    ]
+                },
+                "interval": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "money_sum": {
+                    "type": "string",
+                    "example": "12.30"
+                },
+                "next_date": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "integer"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Transfer": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
                 "date_time": {
                     "type": "string"
                 },
                 "description": {
                     "type": "string"
                 },
+                "from_account_uuid": {
+                    "type": "string"
+                },
                 "money_sum": {
-                    "type": "number"
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "to_account_uuid": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.Webhook": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_uuid": {
+                    "type": "string"
+                },
+                "uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "entity.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "enum": [
+                        "pending",
+                        "delivered",
+                        "failed"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.DeliveryStatus"
+                        }
+                    ]
                 },
                 "uuid": {
                     "type": "string"
+                },
+                "webhook_uuid": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_Account": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Account"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_AuditRecord": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.AuditRecord"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_Budget": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Budget"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_Category": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Category"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_Operation": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Operation"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_RecurringOperation": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.RecurringOperation"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_Webhook": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Webhook"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "operation-service_pkg_pagination.Page-entity_WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.WebhookDelivery"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
                 }
             }
         },
+        "types.BudgetPeriod": {
+            "type": "string",
+            "enum": [
+                "week",
+                "month",
+                "year"
+            ],
+            "x-enum-varnames": [
+                "WeekPeriod",
+                "MonthPeriod",
+                "YearPeriod"
+            ]
+        },
         "types.CategoryType": {
             "type": "string",
             "enum": [
@@ -574,6 +3796,42 @@ const docTemplate = `{
                 "IncomeType",
                 "ExpenseType"
             ]
+        },
+        "types.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "PendingDelivery",
+                "DeliveredDelivery",
+                "FailedDelivery"
+            ]
+        },
+        "types.Frequency": {
+            "type": "string",
+            "enum": [
+                "daily",
+                "weekly",
+                "monthly",
+                "yearly"
+            ],
+            "x-enum-varnames": [
+                "Daily",
+                "Weekly",
+                "Monthly",
+                "Yearly"
+            ]
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "JWT as \"Bearer \u003ctoken\u003e\", the user uuid is taken from the sub claim",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
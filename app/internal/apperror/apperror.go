@@ -2,26 +2,75 @@ package apperror
 
 import (
 	"encoding/json"
-	"fmt"
+	"errors"
+	"net/http"
+)
+
+// Error codes of the catalogue, each kind of error has its own code and HTTP status.
+const (
+	validationCode   = "OS-000400"
+	unauthorizedCode = "OS-000401"
+	forbiddenCode    = "OS-000403"
+	notFoundCode     = "OS-000404"
+	conflictCode     = "OS-000409"
+	internalCode     = "OS-000500"
+)
+
+// Problem types of the catalogue (RFC 7807), relative to the API host.
+const (
+	validationType   = "/problems/validation"
+	unauthorizedType = "/problems/unauthorized"
+	forbiddenType    = "/problems/forbidden"
+	notFoundType     = "/problems/not-found"
+	conflictType     = "/problems/conflict"
+	internalType     = "/problems/internal"
+)
+
+// Codes of field errors tell clients why the field is invalid.
+const (
+	CodeRequired   = "required"
+	CodeInvalid    = "invalid"
+	CodeOutOfRange = "out_of_range"
+	CodeNotFound   = "not_found"
+	CodeMismatch   = "mismatch"
 )
 
 var (
-	ErrNotFound = NewAppError("OS-000404", "not found", "not found")
+	ErrNotFound     = NotFoundError("not found")
+	ErrUnauthorized = UnauthorizedError("unauthorized")
+	ErrForbidden    = ForbiddenError("forbidden")
+	ErrInternal     = InternalError("internal server error")
 )
 
+// AppError is sent to clients as RFC 7807 problem details extended with the code of
+// the catalogue, the request id and invalid fields of the request.
 type AppError struct {
-	Err              error  `json:"-"`
-	Code             string `json:"code,omitempty"`
-	Message          string `json:"message,omitempty"`
-	DeveloperMessage string `json:"developer_message,omitempty"`
+	Err       error        `json:"-"`
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
 }
 
-func NewAppError(code, message, developerMessage string) *AppError {
+// FieldError describes an invalid field of the request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func NewAppError(status int, code, problemType, detail string) *AppError {
 	return &AppError{
-		Err:              fmt.Errorf(message),
-		Code:             code,
-		Message:          message,
-		DeveloperMessage: developerMessage,
+		Err:    errors.New(detail),
+		Type:   problemType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
 	}
 }
 
@@ -41,10 +90,57 @@ func (e *AppError) Marshal() []byte {
 	return bytes
 }
 
-func BadRequestError(message string) *AppError {
-	return NewAppError("OS-000400", message, "something wrong with user data")
+// ValidationError reports invalid data of the request.
+func ValidationError(detail string) *AppError {
+	return NewAppError(http.StatusBadRequest, validationCode, validationType, detail)
+}
+
+// FieldValidationError reports a single invalid field of the request.
+func FieldValidationError(field, code, message string) *AppError {
+	err := ValidationError(message)
+	err.Errors = []FieldError{{Field: field, Code: code, Message: message}}
+	return err
 }
 
-func systemError(developerMessage string) *AppError {
-	return NewAppError("OS-000418", "internal system error", developerMessage)
+func UnauthorizedError(detail string) *AppError {
+	return NewAppError(http.StatusUnauthorized, unauthorizedCode, unauthorizedType, detail)
+}
+
+func ForbiddenError(detail string) *AppError {
+	return NewAppError(http.StatusForbidden, forbiddenCode, forbiddenType, detail)
+}
+
+func NotFoundError(detail string) *AppError {
+	return NewAppError(http.StatusNotFound, notFoundCode, notFoundType, detail)
+}
+
+// ConflictError reports a change which conflicts with the current state of resources.
+func ConflictError(detail string) *AppError {
+	return NewAppError(http.StatusConflict, conflictCode, conflictType, detail)
+}
+
+// InternalError is sent instead of errors which are not app errors, their details stay in logs.
+func InternalError(detail string) *AppError {
+	return NewAppError(http.StatusInternalServerError, internalCode, internalType, detail)
+}
+
+// FieldErrors collects invalid fields of the request, so that all of them are reported at once.
+type FieldErrors []FieldError
+
+func (e *FieldErrors) Add(field, code, message string) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns the validation error with all collected fields or nil if there are none.
+func (e FieldErrors) Err() error {
+	switch len(e) {
+	case 0:
+		return nil
+	case 1:
+		return FieldValidationError(e[0].Field, e[0].Code, e[0].Message)
+	default:
+		err := ValidationError("request has invalid fields")
+		err.Errors = e
+		return err
+	}
 }
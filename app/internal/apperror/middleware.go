@@ -3,30 +3,37 @@ package apperror
 import (
 	"errors"
 	"net/http"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/requestctx"
 )
 
 type appHandler func(http.ResponseWriter, *http.Request) error
 
 func Middleware(h appHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var appErr *AppError
-		err := h(w, r)
-		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			if errors.As(err, &appErr) {
-				//check other custom errors
-				if errors.Is(err, ErrNotFound) {
-					w.WriteHeader(http.StatusNotFound)
-					_, _ = w.Write(ErrNotFound.Marshal())
-					return
-				}
-
-				w.WriteHeader(http.StatusBadRequest)
-				_, _ = w.Write(appErr.Marshal())
-				return
-			}
-			w.WriteHeader(http.StatusTeapot)
-			_, _ = w.Write(systemError(err.Error()).Marshal())
+		if err := h(w, r); err != nil {
+			Write(w, r, err)
 		}
 	}
 }
+
+// Write sends the error as problem details with the status of its kind. Errors which are not
+// app errors are logged with the request id and sent as internal errors without details.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	requestID := requestctx.RequestID(r.Context())
+
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		logging.GetLogger().Errorf("request %s %s %s failed: %v", requestID, r.Method, r.URL.Path, err)
+		appErr = ErrInternal
+	}
+
+	// errors of the catalogue are shared, so the request id is set on a copy
+	response := *appErr
+	response.Instance = r.URL.Path
+	response.RequestID = requestID
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.Status)
+	_, _ = w.Write(response.Marshal())
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/validation"
+	"operation-service/pkg/jwt"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/requestctx"
+	"strings"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	apiPrefix    = "/api/"
+)
+
+var (
+	errMissingToken = errors.New("bearer token is missing")
+	errMissingUser  = errors.New("token has no user claim")
+	errInvalidUser  = errors.New("user claim of token is not uuid")
+)
+
+// Authenticator authenticates requests with JWT bearer tokens.
+type Authenticator struct {
+	verifier   *jwt.Verifier
+	userClaim  string
+	rolesClaim string
+	adminRole  string
+	public     []string
+	logger     *logging.Logger
+}
+
+// NewAuthenticator creates an authenticator which takes the user uuid from userClaim of the token
+// and requires the token for every API request except requests to the public paths and their subpaths.
+// Users having adminRole among roles of rolesClaim are administrators.
+func NewAuthenticator(verifier *jwt.Verifier, userClaim, rolesClaim, adminRole string, public []string,
+	logger *logging.Logger) *Authenticator {
+	return &Authenticator{
+		verifier:   verifier,
+		userClaim:  userClaim,
+		rolesClaim: rolesClaim,
+		adminRole:  adminRole,
+		public:     public,
+		logger:     logger,
+	}
+}
+
+// Middleware puts the uuid of the authenticated user and whether the user is an administrator
+// into the request context. API requests without a valid token are rejected unless their path
+// is public, other requests are passed as is.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.isProtected(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		claims, err := a.authenticate(r)
+		if err != nil {
+			a.logger.Debugf("request %s is not authenticated: %v", requestctx.RequestID(r.Context()), err)
+
+			w.Header().Set("WWW-Authenticate", `Bearer realm="operation-service"`)
+			apperror.Write(w, r, apperror.ErrUnauthorized)
+			return
+		}
+
+		ctx := requestctx.WithUserUUID(r.Context(), claims.String(a.userClaim))
+		ctx = requestctx.WithAdmin(ctx, a.isAdmin(claims))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a *Authenticator) authenticate(r *http.Request) (jwt.Claims, error) {
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return nil, errMissingToken
+	}
+
+	claims, err := a.verifier.Verify(strings.TrimPrefix(authorization, bearerPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID := claims.String(a.userClaim)
+	if userUUID == "" {
+		return nil, errMissingUser
+	}
+	// the user uuid is compared with uuid columns, so anything else would fail in the database
+	if !validation.IsUUID(userUUID) {
+		return nil, errInvalidUser
+	}
+	return claims, nil
+}
+
+func (a *Authenticator) isAdmin(claims jwt.Claims) bool {
+	if a.adminRole == "" {
+		return false
+	}
+	for _, role := range claims.Strings(a.rolesClaim) {
+		if role == a.adminRole {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Authenticator) isProtected(path string) bool {
+	if !strings.HasPrefix(path, apiPrefix) {
+		return false
+	}
+	for _, prefix := range a.public {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"operation-service/pkg/jwt"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/requestctx"
+	"testing"
+	"time"
+)
+
+const (
+	testSecret = "0123456789abcdef0123456789abcdef"
+	testUser   = "6b7c6a0e-2f1d-4c3b-9a8e-1d2c3b4a5f60"
+)
+
+func signToken(t *testing.T, secret string, claims map[string]any) string {
+	t.Helper()
+	segment := func(v any) string {
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(bytes)
+	}
+
+	signed := segment(map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + segment(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMiddleware(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	claims := func(sub string, roles ...string) map[string]any {
+		return map[string]any{"sub": sub, "roles": roles, "exp": exp}
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		token      string
+		wantStatus int
+		wantUser   string
+		wantAdmin  bool
+	}{
+		{name: "user", path: "/api/operations", token: signToken(t, testSecret, claims(testUser)),
+			wantStatus: http.StatusOK, wantUser: testUser},
+		{name: "administrator", path: "/api/exchange-rates", token: signToken(t, testSecret, claims(testUser, "admin")),
+			wantStatus: http.StatusOK, wantUser: testUser, wantAdmin: true},
+		{name: "other role", path: "/api/exchange-rates", token: signToken(t, testSecret, claims(testUser, "user")),
+			wantStatus: http.StatusOK, wantUser: testUser},
+		{name: "without token", path: "/api/operations", wantStatus: http.StatusUnauthorized},
+		{name: "signed with another secret", path: "/api/operations",
+			token: signToken(t, "another secret", claims(testUser)), wantStatus: http.StatusUnauthorized},
+		{name: "expired", path: "/api/operations", wantStatus: http.StatusUnauthorized,
+			token: signToken(t, testSecret, map[string]any{"sub": testUser, "exp": time.Now().Add(-time.Hour).Unix()})},
+		{name: "user is not uuid", path: "/api/operations", token: signToken(t, testSecret, claims("1 OR 1=1")),
+			wantStatus: http.StatusUnauthorized},
+		{name: "without user", path: "/api/operations", token: signToken(t, testSecret, claims("")),
+			wantStatus: http.StatusUnauthorized},
+		{name: "public path", path: "/api/heartbeat", wantStatus: http.StatusOK},
+		{name: "subpath of public path", path: "/api/heartbeat/details", wantStatus: http.StatusOK},
+		{name: "path prefixed by public path", path: "/api/heartbeats", wantStatus: http.StatusUnauthorized},
+		{name: "not api", path: "/swagger/index.html", wantStatus: http.StatusOK},
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	authenticator := NewAuthenticator(jwt.NewVerifier(testSecret, nil, "", ""), "sub", "roles", "admin",
+		[]string{"/api/heartbeat"}, &logging.Logger{Entry: logrus.NewEntry(logger)})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUser string
+			var gotAdmin bool
+			handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotUser, _ = requestctx.UserUUID(r.Context())
+				gotAdmin = requestctx.IsAdmin(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("WWW-Authenticate header is missing")
+			}
+			if gotUser != tt.wantUser || gotAdmin != tt.wantAdmin {
+				t.Errorf("user = %q, admin = %t, want %q, %t", gotUser, gotAdmin, tt.wantUser, tt.wantAdmin)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ilyakaznacheev/cleanenv"
 	"operation-service/pkg/logging"
 	"sync"
+	"time"
 )
 
 type Config struct {
@@ -19,6 +20,48 @@ type Config struct {
 		Username string `yaml:"username"`
 		Password string `yaml:"password"`
 	} `yaml:"postgres" env-required:"true"`
+	Migrations struct {
+		// Auto applies pending migrations at startup, otherwise they are applied by the migrate command
+		Auto bool `yaml:"auto" env-default:"true"`
+	} `yaml:"migrations"`
+	Scheduler struct {
+		Interval time.Duration `yaml:"interval" env-default:"1m"`
+	} `yaml:"scheduler"`
+	Webhook struct {
+		Interval    time.Duration `yaml:"interval" env-default:"10s"`
+		Timeout     time.Duration `yaml:"timeout" env-default:"10s"`
+		MaxAttempts int           `yaml:"max_attempts" env-default:"10"`
+	} `yaml:"webhook"`
+	Outbox struct {
+		// Publisher is "webhook", which posts events to URL signed with Secret, or "memory", which
+		// keeps only the latest events in memory and is meant for local development
+		Publisher string        `yaml:"publisher" env-required:"true"`
+		URL       string        `yaml:"url"`
+		Secret    string        `yaml:"secret"`
+		Interval  time.Duration `yaml:"interval" env-default:"5s"`
+		// ClaimTimeout is how long a relay may publish a batch before the events are claimed again
+		ClaimTimeout time.Duration `yaml:"claim_timeout" env-default:"5m"`
+		BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	} `yaml:"outbox"`
+	Purge struct {
+		// Retention is how long deleted operations, transfers and categories are kept before they are purged
+		Retention time.Duration `yaml:"retention" env-default:"720h"`
+		Interval  time.Duration `yaml:"interval" env-default:"1h"`
+	} `yaml:"purge"`
+	Auth struct {
+		// Secret verifies HS256 tokens, JWKSFile holds public keys verifying RS256 tokens,
+		// at least one of them must be set. Secret is not kept in the config file.
+		Secret   string `yaml:"secret" env:"AUTH_SECRET"`
+		JWKSFile string `yaml:"jwks_file" env:"AUTH_JWKS_FILE"`
+		// Issuer and Audience are checked if set
+		Issuer   string `yaml:"issuer"`
+		Audience string `yaml:"audience"`
+		// UserClaim is the claim holding the user uuid
+		UserClaim string `yaml:"user_claim" env-default:"sub"`
+		// RolesClaim holds roles of the user, users having AdminRole may change exchange rates
+		RolesClaim string `yaml:"roles_claim" env-default:"roles"`
+		AdminRole  string `yaml:"admin_role" env-default:"admin"`
+	} `yaml:"auth"`
 }
 
 var instance *Config
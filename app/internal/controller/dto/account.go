@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+)
+
+type CreateAccountDTO struct {
+	// UserUUID is the authenticated user's uuid if empty
+	UserUUID string         `json:"user_uuid,omitempty"`
+	Name     string         `json:"name"`
+	Currency types.Currency `json:"currency" example:"USD"`
+}
+
+type UpdateAccountDTO struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+func (d CreateAccountDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.UUID(&errs, "user_uuid", d.UserUUID)
+	if validation.Required(&errs, "name", d.Name) {
+		validation.MaxLength(&errs, "name", d.Name, validation.NameMaxLength)
+	}
+	validation.RequiredCurrency(&errs, "currency", d.Currency)
+	return errs.Err()
+}
+
+func (d UpdateAccountDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "uuid", d.UUID)
+	validation.MaxLength(&errs, "name", d.Name, validation.NameMaxLength)
+	return errs.Err()
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+)
+
+type CreateBudgetDTO struct {
+	CategoryUUID string             `json:"category_uuid"`
+	Period       types.BudgetPeriod `json:"period" enums:"week,month,year"`
+	Limit        types.Money        `json:"limit" swaggertype:"string" example:"500.00"`
+	Currency     types.Currency     `json:"currency" example:"USD"`
+	Rollover     bool               `json:"rollover"`
+}
+
+type UpdateBudgetDTO struct {
+	UUID     string             `json:"uuid"`
+	Period   types.BudgetPeriod `json:"period" enums:"week,month,year"`
+	Limit    types.Money        `json:"limit" swaggertype:"string" example:"500.00"`
+	Rollover *bool              `json:"rollover,omitempty"`
+}
+
+func (d CreateBudgetDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "category_uuid", d.CategoryUUID)
+	validation.OneOf(&errs, "period", d.Period, types.WeekPeriod, types.MonthPeriod, types.YearPeriod)
+	validation.PositiveMoney(&errs, "limit", d.Limit)
+	validation.Currency(&errs, "currency", d.Currency)
+	return errs.Err()
+}
+
+func (d UpdateBudgetDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "uuid", d.UUID)
+	validation.OneOf(&errs, "period", d.Period, types.WeekPeriod, types.MonthPeriod, types.YearPeriod)
+	validation.NonNegativeMoney(&errs, "limit", d.Limit)
+	return errs.Err()
+}
@@ -1,14 +1,67 @@
 package dto
 
-import "operation-service/internal/domain/types"
+import (
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+)
 
 type CreateCategoryDTO struct {
-	UserUUID string             `json:"user_uuid"`
-	Name     string             `json:"name"`
-	Type     types.CategoryType `json:"type"`
+	// UserUUID is the authenticated user's uuid if empty
+	UserUUID   string             `json:"user_uuid,omitempty"`
+	Name       string             `json:"name"`
+	Type       types.CategoryType `json:"type"`
+	Currency   types.Currency     `json:"currency,omitempty" example:"USD"`
+	ParentUUID string             `json:"parent_uuid,omitempty"`
 }
 
 type UpdateCategoryDTO struct {
-	UUID string `json:"uuid"`
-	Name string `json:"name"`
+	UUID     string         `json:"uuid"`
+	Name     string         `json:"name"`
+	Currency types.Currency `json:"currency,omitempty" example:"USD"`
+	// ParentUUID moves the category under another one, empty string makes it a root category
+	ParentUUID *string `json:"parent_uuid,omitempty"`
+}
+
+type DeleteCategoryDTO struct {
+	UUID string
+	// ReassignTo is a category of the same user and type to move operations to
+	ReassignTo string
+	// Cascade deletes operations of the category
+	Cascade bool
+}
+
+func (d CreateCategoryDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.UUID(&errs, "user_uuid", d.UserUUID)
+	if validation.Required(&errs, "name", d.Name) {
+		validation.MaxLength(&errs, "name", d.Name, validation.NameMaxLength)
+	}
+	if validation.Required(&errs, "type", string(d.Type)) {
+		validation.OneOf(&errs, "type", d.Type, types.IncomeType, types.ExpenseType)
+	}
+	validation.Currency(&errs, "currency", d.Currency)
+	validation.UUID(&errs, "parent_uuid", d.ParentUUID)
+	return errs.Err()
+}
+
+func (d UpdateCategoryDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "uuid", d.UUID)
+	validation.MaxLength(&errs, "name", d.Name, validation.NameMaxLength)
+	validation.Currency(&errs, "currency", d.Currency)
+	if d.ParentUUID != nil {
+		validation.UUID(&errs, "parent_uuid", *d.ParentUUID)
+	}
+	return errs.Err()
+}
+
+func (d DeleteCategoryDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "uuid", d.UUID)
+	validation.UUID(&errs, "reassign_to", d.ReassignTo)
+	if d.ReassignTo != "" && d.Cascade {
+		errs.Add("reassign_to", apperror.CodeMismatch, "reassign_to and cascade can not be specified together")
+	}
+	return errs.Err()
 }
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+	"time"
+)
+
+type CreateExchangeRateDTO struct {
+	Base  types.Currency `json:"base" example:"EUR"`
+	Quote types.Currency `json:"quote" example:"USD"`
+	Rate  types.Rate     `json:"rate" swaggertype:"string" example:"1.0845"`
+	Date  string         `json:"date" example:"2024-05-01"`
+}
+
+type GetExchangeRateDTO struct {
+	Base  types.Currency
+	Quote types.Currency
+	Date  *time.Time
+}
+
+// CreateExchangeRatesDTO is a batch of exchange rates saved at once.
+type CreateExchangeRatesDTO []CreateExchangeRateDTO
+
+func (d CreateExchangeRatesDTO) Validate() error {
+	if len(d) == 0 {
+		return apperror.ValidationError("no exchange rates provided")
+	}
+
+	var errs apperror.FieldErrors
+	for i, rate := range d {
+		rate.validate(&errs, fmt.Sprintf("[%d].", i))
+	}
+	return errs.Err()
+}
+
+func (d CreateExchangeRateDTO) Validate() error {
+	var errs apperror.FieldErrors
+	d.validate(&errs, "")
+	return errs.Err()
+}
+
+// validate adds invalid fields of the rate prefixed with its position in a batch.
+func (d CreateExchangeRateDTO) validate(errs *apperror.FieldErrors, prefix string) {
+	validation.RequiredCurrency(errs, prefix+"base", d.Base)
+	validation.RequiredCurrency(errs, prefix+"quote", d.Quote)
+	if d.Base != "" && d.Base == d.Quote {
+		errs.Add(prefix+"quote", apperror.CodeMismatch, "base and quote currencies must differ")
+	}
+	if d.Rate.IsZero() {
+		errs.Add(prefix+"rate", apperror.CodeOutOfRange, fmt.Sprintf("%srate must be positive", prefix))
+	}
+	validation.RequiredDate(errs, prefix+"date", d.Date)
+}
+
+func (d GetExchangeRateDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredCurrency(&errs, "base", d.Base)
+	validation.RequiredCurrency(&errs, "quote", d.Quote)
+	validation.MinDate(&errs, "date", d.Date)
+	return errs.Err()
+}
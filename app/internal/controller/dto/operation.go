@@ -1,14 +1,106 @@
 package dto
 
+import (
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
 type CreateOperationDTO struct {
-	CategoryUUID string  `json:"category_uuid"`
-	MoneySum     float64 `json:"money_sum"`
-	Description  string  `json:"description"`
+	CategoryUUID string         `json:"category_uuid"`
+	AccountUUID  string         `json:"account_uuid,omitempty"`
+	MoneySum     types.Money    `json:"money_sum" swaggertype:"string" example:"12.30"`
+	Currency     types.Currency `json:"currency,omitempty" example:"USD"`
+	Description  string         `json:"description"`
+	DateTime     *time.Time     `json:"date_time,omitempty"`
 }
 
 type UpdateOperationDTO struct {
-	UUID         string  `json:"uuid"`
-	CategoryUUID string  `json:"category_uuid"`
-	MoneySum     float64 `json:"money_sum"`
-	Description  string  `json:"description"`
+	UUID         string         `json:"uuid"`
+	CategoryUUID string         `json:"category_uuid"`
+	AccountUUID  string         `json:"account_uuid,omitempty"`
+	MoneySum     types.Money    `json:"money_sum" swaggertype:"string" example:"12.30"`
+	Currency     types.Currency `json:"currency,omitempty" example:"USD"`
+	Description  string         `json:"description"`
+	DateTime     *time.Time     `json:"date_time,omitempty"`
+}
+
+type FindOperationsDTO struct {
+	UserUUID      string
+	CategoryUUIDs []string
+	AccountUUID   string
+	DateFrom      *time.Time
+	DateTo        *time.Time
+	MinSum        *types.Money
+	MaxSum        *types.Money
+	Currency      types.Currency
+	Description   string
+	Page          pagination.Params
+}
+
+type GetBalanceDTO struct {
+	UserUUID string
+	DateFrom *time.Time
+	DateTo   *time.Time
+	Currency types.Currency
+}
+
+func (d CreateOperationDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "category_uuid", d.CategoryUUID)
+	validation.UUID(&errs, "account_uuid", d.AccountUUID)
+	validation.PositiveMoney(&errs, "money_sum", d.MoneySum)
+	validation.Currency(&errs, "currency", d.Currency)
+	validation.MaxLength(&errs, "description", d.Description, validation.DescriptionMaxLength)
+	validation.DateTime(&errs, "date_time", d.DateTime)
+	return errs.Err()
+}
+
+func (d UpdateOperationDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "uuid", d.UUID)
+	validation.UUID(&errs, "category_uuid", d.CategoryUUID)
+	validation.UUID(&errs, "account_uuid", d.AccountUUID)
+	validation.NonNegativeMoney(&errs, "money_sum", d.MoneySum)
+	validation.Currency(&errs, "currency", d.Currency)
+	validation.MaxLength(&errs, "description", d.Description, validation.DescriptionMaxLength)
+	validation.DateTime(&errs, "date_time", d.DateTime)
+	return errs.Err()
+}
+
+func (d FindOperationsDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.UUID(&errs, "user_uuid", d.UserUUID)
+	for i, categoryUUID := range d.CategoryUUIDs {
+		validation.UUID(&errs, fmt.Sprintf("category_uuid[%d]", i), categoryUUID)
+	}
+	validation.UUID(&errs, "account_uuid", d.AccountUUID)
+	validation.DateRange(&errs, "date_from", "date_to", d.DateFrom, d.DateTo)
+	if d.MinSum != nil {
+		validation.NonNegativeMoney(&errs, "min_sum", *d.MinSum)
+	}
+	if d.MaxSum != nil {
+		validation.NonNegativeMoney(&errs, "max_sum", *d.MaxSum)
+	}
+	if d.MinSum != nil && d.MaxSum != nil && d.MinSum.Cmp(*d.MaxSum) > 0 {
+		errs.Add("max_sum", apperror.CodeOutOfRange, "max_sum must not be less than min_sum")
+	}
+	validation.Currency(&errs, "currency", d.Currency)
+	validation.MaxLength(&errs, "description", d.Description, validation.DescriptionMaxLength)
+	// operations are ordered by date time, so their cursors must have one
+	if d.Page.After != nil && d.Page.After.DateTime == nil {
+		errs.Add("cursor", apperror.CodeInvalid, pagination.ErrInvalidCursor.Error())
+	}
+	return errs.Err()
+}
+
+func (d GetBalanceDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.UUID(&errs, "user_uuid", d.UserUUID)
+	validation.DateRange(&errs, "date_from", "date_to", d.DateFrom, d.DateTo)
+	validation.Currency(&errs, "currency", d.Currency)
+	return errs.Err()
 }
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+	"time"
+)
+
+// maxRecurringStartAge limits how far in the past a schedule may start, as the operations
+// due since the start are created at once.
+const maxRecurringStartAge = 366 * 24 * time.Hour
+
+// maxRecurringInterval and maxRecurringCount keep dates of all occurrences within the range
+// of time.Time and both numbers within INTEGER columns.
+const (
+	maxRecurringInterval = 1000
+	maxRecurringCount    = 10000
+)
+
+type CreateRecurringOperationDTO struct {
+	CategoryUUID string          `json:"category_uuid"`
+	AccountUUID  string          `json:"account_uuid"`
+	MoneySum     types.Money     `json:"money_sum" swaggertype:"string" example:"12.30"`
+	Currency     types.Currency  `json:"currency" example:"USD"`
+	Description  string          `json:"description"`
+	Frequency    types.Frequency `json:"frequency" enums:"daily,weekly,monthly,yearly"`
+	Interval     int             `json:"interval" example:"1"`
+	StartDate    *time.Time      `json:"start_date,omitempty"`
+	EndDate      *time.Time      `json:"end_date,omitempty"`
+	Count        int             `json:"count" example:"12"`
+}
+
+func (d CreateRecurringOperationDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "category_uuid", d.CategoryUUID)
+	validation.UUID(&errs, "account_uuid", d.AccountUUID)
+	validation.PositiveMoney(&errs, "money_sum", d.MoneySum)
+	validation.Currency(&errs, "currency", d.Currency)
+	validation.MaxLength(&errs, "description", d.Description, validation.DescriptionMaxLength)
+	if validation.Required(&errs, "frequency", string(d.Frequency)) {
+		validation.OneOf(&errs, "frequency", d.Frequency, types.Daily, types.Weekly, types.Monthly, types.Yearly)
+	}
+	if d.Interval < 0 {
+		errs.Add("interval", apperror.CodeOutOfRange, "interval must be positive")
+	} else if d.Interval > maxRecurringInterval {
+		errs.Add("interval", apperror.CodeOutOfRange, fmt.Sprintf("interval must not exceed %d", maxRecurringInterval))
+	}
+	if d.Count < 0 {
+		errs.Add("count", apperror.CodeOutOfRange, "count can not be negative")
+	} else if d.Count > maxRecurringCount {
+		errs.Add("count", apperror.CodeOutOfRange, fmt.Sprintf("count must not exceed %d", maxRecurringCount))
+	}
+	if d.StartDate != nil && d.StartDate.Before(time.Now().Add(-maxRecurringStartAge)) {
+		errs.Add("start_date", apperror.CodeOutOfRange, "start_date must not be more than a year in the past")
+	}
+	validation.DateRange(&errs, "start_date", "end_date", d.StartDate, d.EndDate)
+	return errs.Err()
+}
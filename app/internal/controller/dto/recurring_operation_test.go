@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"errors"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"testing"
+)
+
+func TestCreateRecurringOperationDTOValidate(t *testing.T) {
+	valid := func() CreateRecurringOperationDTO {
+		return CreateRecurringOperationDTO{
+			CategoryUUID: "6b7c6a0e-2f1d-4c3b-9a8e-1d2c3b4a5f60",
+			MoneySum:     types.MoneyFromMinor(1230),
+			Frequency:    types.Monthly,
+			Interval:     1,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		change    func(d *CreateRecurringOperationDTO)
+		wantField string
+		wantCode  string
+	}{
+		{name: "valid", change: func(d *CreateRecurringOperationDTO) {}},
+		{name: "greatest interval", change: func(d *CreateRecurringOperationDTO) { d.Interval = maxRecurringInterval }},
+		{name: "greatest count", change: func(d *CreateRecurringOperationDTO) { d.Count = maxRecurringCount }},
+		{name: "negative interval", change: func(d *CreateRecurringOperationDTO) { d.Interval = -1 },
+			wantField: "interval", wantCode: apperror.CodeOutOfRange},
+		{name: "too large interval", change: func(d *CreateRecurringOperationDTO) { d.Interval = 1 << 40 },
+			wantField: "interval", wantCode: apperror.CodeOutOfRange},
+		{name: "negative count", change: func(d *CreateRecurringOperationDTO) { d.Count = -1 },
+			wantField: "count", wantCode: apperror.CodeOutOfRange},
+		{name: "too large count", change: func(d *CreateRecurringOperationDTO) { d.Count = maxRecurringCount + 1 },
+			wantField: "count", wantCode: apperror.CodeOutOfRange},
+		{name: "unknown frequency", change: func(d *CreateRecurringOperationDTO) { d.Frequency = "hourly" },
+			wantField: "frequency", wantCode: apperror.CodeInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid()
+			tt.change(&d)
+
+			err := d.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var appErr *apperror.AppError
+			if !errors.As(err, &appErr) || len(appErr.Errors) != 1 {
+				t.Fatalf("Validate() error = %v, want a single field error", err)
+			}
+			if got := appErr.Errors[0]; got.Field != tt.wantField || got.Code != tt.wantCode {
+				t.Errorf("Validate() field error = %s %s, want %s %s", got.Field, got.Code, tt.wantField,
+					tt.wantCode)
+			}
+		})
+	}
+}
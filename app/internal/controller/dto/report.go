@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+	"time"
+)
+
+type GetCategoryReportDTO struct {
+	UserUUID string
+	DateFrom *time.Time
+	DateTo   *time.Time
+	Bucket   types.ReportBucket
+	Currency types.Currency
+	Rollup   bool
+}
+
+func (d GetCategoryReportDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.UUID(&errs, "user_uuid", d.UserUUID)
+	validation.DateRange(&errs, "date_from", "date_to", d.DateFrom, d.DateTo)
+	validation.OneOf(&errs, "bucket", d.Bucket, types.DayBucket, types.WeekBucket, types.MonthBucket)
+	validation.Currency(&errs, "currency", d.Currency)
+	return errs.Err()
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+	"time"
+)
+
+type CreateTransferDTO struct {
+	FromAccountUUID string      `json:"from_account_uuid"`
+	ToAccountUUID   string      `json:"to_account_uuid"`
+	MoneySum        types.Money `json:"money_sum" swaggertype:"string" example:"100.00"`
+	Description     string      `json:"description"`
+	DateTime        *time.Time  `json:"date_time,omitempty"`
+}
+
+type UpdateTransferDTO struct {
+	UUID            string      `json:"uuid"`
+	FromAccountUUID string      `json:"from_account_uuid"`
+	ToAccountUUID   string      `json:"to_account_uuid"`
+	MoneySum        types.Money `json:"money_sum" swaggertype:"string" example:"100.00"`
+	Description     string      `json:"description"`
+	DateTime        *time.Time  `json:"date_time,omitempty"`
+}
+
+func (d CreateTransferDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "from_account_uuid", d.FromAccountUUID)
+	validation.RequiredUUID(&errs, "to_account_uuid", d.ToAccountUUID)
+	validation.PositiveMoney(&errs, "money_sum", d.MoneySum)
+	validation.MaxLength(&errs, "description", d.Description, validation.DescriptionMaxLength)
+	validation.DateTime(&errs, "date_time", d.DateTime)
+	return errs.Err()
+}
+
+func (d UpdateTransferDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, "uuid", d.UUID)
+	validation.UUID(&errs, "from_account_uuid", d.FromAccountUUID)
+	validation.UUID(&errs, "to_account_uuid", d.ToAccountUUID)
+	validation.NonNegativeMoney(&errs, "money_sum", d.MoneySum)
+	validation.MaxLength(&errs, "description", d.Description, validation.DescriptionMaxLength)
+	validation.DateTime(&errs, "date_time", d.DateTime)
+	return errs.Err()
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"operation-service/internal/apperror"
+	"operation-service/internal/validation"
+)
+
+type CreateWebhookDTO struct {
+	// UserUUID is the authenticated user's uuid if empty
+	UserUUID string `json:"user_uuid,omitempty"`
+	URL      string `json:"url" example:"https://example.com/hooks/finances"`
+	Secret   string `json:"secret"`
+}
+
+const minWebhookSecretLength = 16
+
+func (d CreateWebhookDTO) Validate() error {
+	var errs apperror.FieldErrors
+	validation.UUID(&errs, "user_uuid", d.UserUUID)
+	if validation.Required(&errs, "url", d.URL) {
+		validation.HTTPURL(&errs, "url", d.URL)
+	}
+	if validation.Required(&errs, "secret", d.Secret) {
+		validation.MinLength(&errs, "secret", d.Secret, minWebhookSecretLength)
+		validation.MaxLength(&errs, "secret", d.Secret, validation.SecretMaxLength)
+	}
+	return errs.Err()
+}
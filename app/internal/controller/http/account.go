@@ -0,0 +1,307 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/utils"
+)
+
+const (
+	accountURL         = "/api/accounts"
+	accountByIdURL     = "/api/accounts/one/:uuid"
+	accountBalanceURL  = "/api/accounts/one/:uuid/balance"
+	accountByUserIdURL = "/api/accounts/user_uuid/:user_uuid"
+)
+
+type AccountService interface {
+	Create(ctx context.Context, dto dto.CreateAccountDTO) (string, error)
+	GetByUUID(ctx context.Context, uuid string) (entity.Account, error)
+	GetByUserUUID(ctx context.Context, uuid string, page pagination.Params) (pagination.Page[entity.Account], error)
+	GetBalance(ctx context.Context, uuid string) (entity.AccountBalance, error)
+	Update(ctx context.Context, dto dto.UpdateAccountDTO) error
+	Delete(ctx context.Context, uuid string) error
+}
+
+type accountHandler struct {
+	service AccountService
+	logger  *logging.Logger
+}
+
+func NewAccountHandler(service AccountService, logger *logging.Logger) Handler {
+	return &accountHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *accountHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodPost, accountURL, apperror.Middleware(h.CreateAccount))
+	router.HandlerFunc(http.MethodGet, accountByIdURL, apperror.Middleware(h.GetAccountByUUID))
+	router.HandlerFunc(http.MethodGet, accountBalanceURL, apperror.Middleware(h.GetAccountBalance))
+	router.HandlerFunc(http.MethodGet, accountByUserIdURL, apperror.Middleware(h.GetAccountsByUserUUID))
+	router.HandlerFunc(http.MethodPatch, accountByIdURL, apperror.Middleware(h.PartiallyUpdateAccount))
+	router.HandlerFunc(http.MethodDelete, accountByIdURL, apperror.Middleware(h.DeleteAccount))
+}
+
+// CreateAccount
+// @Summary 	Create account
+// @Description Creates new account (card, cash wallet, etc.)
+// @Tags 		Account
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		input	body 	 dto.CreateAccountDTO	true	"Account data"
+// @Success 	201
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /accounts [post]
+func (h *accountHandler) CreateAccount(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Create account")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	var createdAccount dto.CreateAccountDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&createdAccount); err != nil {
+		return apperror.ValidationError("invalid JSON body")
+	}
+
+	if err := createdAccount.Validate(); err != nil {
+		return err
+	}
+
+	accountUUID, err := h.service.Create(r.Context(), createdAccount)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", accountURL, accountUUID))
+	w.WriteHeader(http.StatusCreated)
+
+	h.logger.Info("Create account successfully")
+	return nil
+}
+
+// GetAccountByUUID
+// @Summary 	Get account by uuid
+// @Description Get account by uuid
+// @Tags 		Account
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Account's uuid"
+// @Success 	200		{object} entity.Account "Account"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Account not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/accounts/one/	[get]
+func (h *accountHandler) GetAccountByUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get account by uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	accountUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	account, err := h.service.GetByUUID(r.Context(), accountUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(account)
+	if err != nil {
+		return fmt.Errorf("failed to marshal account: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get account by uuid successfully")
+	return nil
+}
+
+// GetAccountBalance
+// @Summary 	Get account balance
+// @Description Get sum of all operations of the account in account's currency
+// @Tags 		Account
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Account's uuid"
+// @Success 	200		{object} entity.AccountBalance "Account balance"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Account not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/accounts/one/{uuid}/balance	[get]
+func (h *accountHandler) GetAccountBalance(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get account balance")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	accountUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	balance, err := h.service.GetBalance(r.Context(), accountUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(balance)
+	if err != nil {
+		return fmt.Errorf("failed to marshal account balance: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get account balance successfully")
+	return nil
+}
+
+// GetAccountsByUserUUID
+// @Summary 	Get accounts by user's uuid
+// @Description Get list of accounts belonging to user
+// @Tags 		Account
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	path 	 string 	true   "User's uuid"
+// @Param 		limit 		query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 		query 	 string 	false  "Cursor of the next page"
+// @Success 	200			{object} pagination.Page[entity.Account] "Page of accounts"
+// @Failure 	400 		{object} apperror.AppError "Validation error"
+// @Failure 	401 		{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 		{object} apperror.AppError "Internal server error"
+// @Router 		/accounts/user_uuid/	[get]
+func (h *accountHandler) GetAccountsByUserUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get accounts by user's uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	userUUID, err := uuidParam(r, "user_uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parsePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	accounts, err := h.service.GetByUserUUID(r.Context(), userUUID, page)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(accounts)
+	if err != nil {
+		return fmt.Errorf("failed to marshal accounts: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get accounts by user's uuid successfully")
+	return nil
+}
+
+// PartiallyUpdateAccount
+// @Summary 	Update account
+// @Description Update account
+// @Tags 		Account
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		uuid 		path 	 string 				true  "Account's uuid"
+// @Param 		input 		body 	 dto.UpdateAccountDTO 	true  "Account's data"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /accounts/one [patch]
+func (h *accountHandler) PartiallyUpdateAccount(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Partially update account")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	accountUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	var updatedAccount dto.UpdateAccountDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&updatedAccount); err != nil {
+		return apperror.ValidationError("invalid JSON body")
+	}
+
+	updatedAccount.UUID = accountUUID
+
+	if err := updatedAccount.Validate(); err != nil {
+		return err
+	}
+
+	err = h.service.Update(r.Context(), updatedAccount)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Update account successfully")
+	return nil
+}
+
+// DeleteAccount
+// @Summary 	Delete account
+// @Description Delete account. Account with operations or used by recurring operations can not be deleted
+// @Tags 		Account
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Account's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Account has operations"
+// @Failure 	404 	{object} apperror.AppError "Account is not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /accounts/one [delete]
+func (h *accountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Delete account")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	accountUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(r.Context(), accountUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Delete account successfully")
+	return nil
+}
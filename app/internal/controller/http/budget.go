@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/utils"
+)
+
+const (
+	budgetURL         = "/api/budgets"
+	budgetByIdURL     = "/api/budgets/one/:uuid"
+	budgetStatusURL   = "/api/budgets/one/:uuid/status"
+	budgetByUserIdURL = "/api/budgets/user_uuid/:user_uuid"
+)
+
+type BudgetService interface {
+	Create(ctx context.Context, dto dto.CreateBudgetDTO) (string, error)
+	GetByUUID(ctx context.Context, uuid string) (entity.Budget, error)
+	GetByUserUUID(ctx context.Context, uuid string, page pagination.Params) (pagination.Page[entity.Budget], error)
+	GetStatus(ctx context.Context, uuid string) (entity.BudgetStatus, error)
+	Update(ctx context.Context, dto dto.UpdateBudgetDTO) error
+	Delete(ctx context.Context, uuid string) error
+}
+
+type budgetHandler struct {
+	service BudgetService
+	logger  *logging.Logger
+}
+
+func NewBudgetHandler(service BudgetService, logger *logging.Logger) Handler {
+	return &budgetHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *budgetHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodPost, budgetURL, apperror.Middleware(h.CreateBudget))
+	router.HandlerFunc(http.MethodGet, budgetByIdURL, apperror.Middleware(h.GetBudgetByUUID))
+	router.HandlerFunc(http.MethodGet, budgetStatusURL, apperror.Middleware(h.GetBudgetStatus))
+	router.HandlerFunc(http.MethodGet, budgetByUserIdURL, apperror.Middleware(h.GetBudgetsByUserUUID))
+	router.HandlerFunc(http.MethodPatch, budgetByIdURL, apperror.Middleware(h.PartiallyUpdateBudget))
+	router.HandlerFunc(http.MethodDelete, budgetByIdURL, apperror.Middleware(h.DeleteBudget))
+}
+
+// CreateBudget
+// @Summary 	Create budget
+// @Description Creates limit of expenses of expense category per week, month or year.
+// @Description Currency defaults to category's one. Category can have one budget per period
+// @Tags 		Budget
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		input	body 	 dto.CreateBudgetDTO	true	"Budget data"
+// @Success 	201
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Category not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /budgets [post]
+func (h *budgetHandler) CreateBudget(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Create budget")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	var createdBudget dto.CreateBudgetDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&createdBudget); err != nil {
+		return decodeError(err)
+	}
+
+	if err := createdBudget.Validate(); err != nil {
+		return err
+	}
+
+	budgetUUID, err := h.service.Create(r.Context(), createdBudget)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", budgetURL, budgetUUID))
+	w.WriteHeader(http.StatusCreated)
+
+	h.logger.Info("Create budget successfully")
+	return nil
+}
+
+// GetBudgetByUUID
+// @Summary 	Get budget by uuid
+// @Description Get budget by uuid
+// @Tags 		Budget
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Budget's uuid"
+// @Success 	200		{object} entity.Budget "Budget"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Budget not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/budgets/one/	[get]
+func (h *budgetHandler) GetBudgetByUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get budget by uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	budgetUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	budget, err := h.service.GetByUUID(r.Context(), budgetUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(budget)
+	if err != nil {
+		return fmt.Errorf("failed to marshal budget: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get budget by uuid successfully")
+	return nil
+}
+
+// GetBudgetStatus
+// @Summary 	Get budget status
+// @Description Get spent, limit and remaining sum of the budget in the current period.
+// @Description Remaining sum includes rolled over sum left or overspent in the previous period
+// @Description if the budget has rollover. Expenses in other currencies are converted at the rate of their day
+// @Tags 		Budget
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Budget's uuid"
+// @Success 	200		{object} entity.BudgetStatus "Budget status"
+// @Failure 	400 	{object} apperror.AppError "Exchange rate is unknown"
+// @Failure 	404 	{object} apperror.AppError "Budget not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/budgets/one/{uuid}/status	[get]
+func (h *budgetHandler) GetBudgetStatus(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get budget status")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	budgetUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	status, err := h.service.GetStatus(r.Context(), budgetUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal budget status: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get budget status successfully")
+	return nil
+}
+
+// GetBudgetsByUserUUID
+// @Summary 	Get budgets by user's uuid
+// @Description Get list of budgets belonging to user
+// @Tags 		Budget
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	path 	 string 	true   "User's uuid"
+// @Param 		limit 		query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 		query 	 string 	false  "Cursor of the next page"
+// @Success 	200			{object} pagination.Page[entity.Budget] "Page of budgets"
+// @Failure 	400 		{object} apperror.AppError "Validation error"
+// @Failure 	401 		{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 		{object} apperror.AppError "Internal server error"
+// @Router 		/budgets/user_uuid/	[get]
+func (h *budgetHandler) GetBudgetsByUserUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get budgets by user's uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	userUUID, err := uuidParam(r, "user_uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parsePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	budgets, err := h.service.GetByUserUUID(r.Context(), userUUID, page)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(budgets)
+	if err != nil {
+		return fmt.Errorf("failed to marshal budgets: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get budgets by user's uuid successfully")
+	return nil
+}
+
+// PartiallyUpdateBudget
+// @Summary 	Update budget
+// @Description Update period, limit or rollover of budget
+// @Tags 		Budget
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		uuid 		path 	 string 				true  "Budget's uuid"
+// @Param 		input 		body 	 dto.UpdateBudgetDTO 	true  "Budget's data"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /budgets/one [patch]
+func (h *budgetHandler) PartiallyUpdateBudget(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Partially update budget")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	budgetUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	var updatedBudget dto.UpdateBudgetDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&updatedBudget); err != nil {
+		return decodeError(err)
+	}
+
+	updatedBudget.UUID = budgetUUID
+
+	if err := updatedBudget.Validate(); err != nil {
+		return err
+	}
+
+	err = h.service.Update(r.Context(), updatedBudget)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Update budget successfully")
+	return nil
+}
+
+// DeleteBudget
+// @Summary 	Delete budget
+// @Description Delete budget
+// @Tags 		Budget
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Budget's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /budgets/one [delete]
+func (h *budgetHandler) DeleteBudget(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Delete budget")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	budgetUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(r.Context(), budgetUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Delete budget successfully")
+	return nil
+}
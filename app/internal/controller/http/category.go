@@ -10,21 +10,27 @@ import (
 	"operation-service/internal/controller/dto"
 	"operation-service/internal/domain/entity"
 	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
 	"operation-service/pkg/utils"
+	"strconv"
 )
 
 const (
 	categoryURL         = "/api/categories"
 	categoryByIdURL     = "/api/categories/one/:uuid"
 	categoryByUserIdURL = "/api/categories/user_uuid/:user_uuid"
+	categoryTreeURL     = "/api/categories/user_uuid/:user_uuid/tree"
+	categoryRestoreURL  = "/api/categories/one/:uuid/restore"
 )
 
 type CategoryService interface {
 	Create(ctx context.Context, dto dto.CreateCategoryDTO) (string, error)
 	GetByUUID(ctx context.Context, uuid string) (entity.Category, error)
-	GetByUserUUID(ctx context.Context, uuid string) ([]entity.Category, error)
+	GetByUserUUID(ctx context.Context, uuid string, page pagination.Params) (pagination.Page[entity.Category], error)
+	GetTreeByUserUUID(ctx context.Context, uuid string) ([]entity.CategoryNode, error)
 	Update(ctx context.Context, dto dto.UpdateCategoryDTO) error
-	Delete(ctx context.Context, uuid string) error
+	Delete(ctx context.Context, dto dto.DeleteCategoryDTO) error
+	Restore(ctx context.Context, uuid string) error
 }
 
 type categoryHandler struct {
@@ -43,19 +49,23 @@ func (h *categoryHandler) Register(router *httprouter.Router) {
 	router.HandlerFunc(http.MethodPost, categoryURL, apperror.Middleware(h.CreateCategory))
 	router.HandlerFunc(http.MethodGet, categoryByIdURL, apperror.Middleware(h.GetCategoryByUUID))
 	router.HandlerFunc(http.MethodGet, categoryByUserIdURL, apperror.Middleware(h.GetCategoriesByUserUUID))
+	router.HandlerFunc(http.MethodGet, categoryTreeURL, apperror.Middleware(h.GetCategoryTreeByUserUUID))
 	router.HandlerFunc(http.MethodPatch, categoryByIdURL, apperror.Middleware(h.PartiallyUpdateCategory))
 	router.HandlerFunc(http.MethodDelete, categoryByIdURL, apperror.Middleware(h.DeleteCategory))
+	router.HandlerFunc(http.MethodPost, categoryRestoreURL, apperror.Middleware(h.RestoreCategory))
 }
 
 // CreateCategory
 // @Summary 	Create category
-// @Description Creates new category
+// @Description Creates new category. Parent category must belong to the same user and have the same type
 // @Tags 		Category
+// @Security 	BearerAuth
 // @Accept		json
 // @Param 		input	body 	 dto.CreateCategoryDTO	true	"Category data"
 // @Success 	201
 // @Failure 	400 	{object} apperror.AppError "Validation error"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	409 	{object} apperror.AppError "Category with the same name and type already exists"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router /categories [post]
 func (h *categoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) error {
@@ -66,11 +76,11 @@ func (h *categoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 	var createdCategory dto.CreateCategoryDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&createdCategory); err != nil {
-		return apperror.BadRequestError("invalid JSON body")
+		return decodeError(err)
 	}
 
-	if createdCategory.UserUUID == "" || createdCategory.Name == "" || createdCategory.Type == "" {
-		return apperror.BadRequestError("missing required fields")
+	if err := createdCategory.Validate(); err != nil {
+		return err
 	}
 
 	categoryUUID, err := h.service.Create(r.Context(), createdCategory)
@@ -89,11 +99,13 @@ func (h *categoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 // @Summary 	Get category by uuid
 // @Description Get category by uuid
 // @Tags 		Category
+// @Security 	BearerAuth
 // @Produce 	json
 // @Param 		uuid 	path 	 string 	true   "Category's uuid"
 // @Success 	200		{object} entity.Category "Category"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
 // @Failure 	404 	{object} apperror.AppError "Category not found"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router 		/categories/one/	[get]
 func (h *categoryHandler) GetCategoryByUUID(w http.ResponseWriter, r *http.Request) error {
@@ -101,10 +113,9 @@ func (h *categoryHandler) GetCategoryByUUID(w http.ResponseWriter, r *http.Reque
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	categoryUUID := params.ByName("uuid")
-	if categoryUUID == "" {
-		return apperror.BadRequestError("category uuid must not be empty")
+	categoryUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
 	}
 
 	category, err := h.service.GetByUUID(r.Context(), categoryUUID)
@@ -132,11 +143,15 @@ func (h *categoryHandler) GetCategoryByUUID(w http.ResponseWriter, r *http.Reque
 // @Summary 	Get categories by user's uuid
 // @Description Get list of categories belonging to user
 // @Tags 		Category
+// @Security 	BearerAuth
 // @Produce 	json
 // @Param 		user_uuid 	path 	 string 	true   "User's uuid"
-// @Success 	200			{object} []entity.Category "Categories"
-// @Failure 	404 		{object} apperror.AppError "User not found"
-// @Failure 	418 		{object} apperror.AppError "Something wrong with application logic"
+// @Param 		limit 		query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 		query 	 string 	false  "Cursor of the next page"
+// @Success 	200			{object} pagination.Page[entity.Category] "Page of categories"
+// @Failure 	400 		{object} apperror.AppError "Validation error"
+// @Failure 	404 		{object} apperror.AppError "User is not the authenticated one"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 		{object} apperror.AppError "Internal server error"
 // @Router 		/categories/user_uuid/	[get]
 func (h *categoryHandler) GetCategoriesByUserUUID(w http.ResponseWriter, r *http.Request) error {
@@ -144,13 +159,18 @@ func (h *categoryHandler) GetCategoriesByUserUUID(w http.ResponseWriter, r *http
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	userUUID := params.ByName("user_uuid")
-	if userUUID == "" {
-		return apperror.BadRequestError("user's uuid must not be empty")
+	userUUID, err := uuidParam(r, "user_uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parsePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
 	}
 
-	categories, err := h.service.GetByUserUUID(r.Context(), userUUID)
+	categories, err := h.service.GetByUserUUID(r.Context(), userUUID, page)
 	if err != nil {
 		return err
 	}
@@ -171,16 +191,62 @@ func (h *categoryHandler) GetCategoriesByUserUUID(w http.ResponseWriter, r *http
 	return nil
 }
 
+// GetCategoryTreeByUserUUID
+// @Summary 	Get category tree by user's uuid
+// @Description Get all categories of user arranged into trees of subcategories
+// @Tags 		Category
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	path 	 string 	true   "User's uuid"
+// @Success 	200			{object} []entity.CategoryNode "Root categories with subcategories"
+// @Failure 	400 		{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 		{object} apperror.AppError "Internal server error"
+// @Router 		/categories/user_uuid/{user_uuid}/tree	[get]
+func (h *categoryHandler) GetCategoryTreeByUserUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get category tree by user's uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	userUUID, err := uuidParam(r, "user_uuid")
+	if err != nil {
+		return err
+	}
+
+	tree, err := h.service.GetTreeByUserUUID(r.Context(), userUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(tree)
+	if err != nil {
+		return fmt.Errorf("failed to marshal category tree: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get category tree by user's uuid successfully")
+	return nil
+}
+
 // PartiallyUpdateCategory
 // @Summary 	Update category
-// @Description Update category
+// @Description Update category. Category can be moved under another category of the same type
+// @Description which is not its subcategory, or made a root one with empty parent uuid
 // @Tags 		Category
+// @Security 	BearerAuth
 // @Accept		json
 // @Param 		uuid 		path 	 string 				true  "Category's uuid"
 // @Param 		input 		body 	 dto.UpdateCategoryDTO true  "Category's data"
 // @Success 	204
 // @Failure 	400 	{object} apperror.AppError "Validation error"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	409 	{object} apperror.AppError "Category with the same name and type already exists"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router /categories/one [patch]
 func (h *categoryHandler) PartiallyUpdateCategory(w http.ResponseWriter, r *http.Request) error {
@@ -188,21 +254,24 @@ func (h *categoryHandler) PartiallyUpdateCategory(w http.ResponseWriter, r *http
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	categoryUUID := params.ByName("uuid")
-	if categoryUUID == "" {
-		return apperror.BadRequestError("category uuid must not be empty")
+	categoryUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
 	}
 
 	var updatedCategory dto.UpdateCategoryDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&updatedCategory); err != nil {
-		return apperror.BadRequestError("invalid JSON body")
+		return decodeError(err)
 	}
 
 	updatedCategory.UUID = categoryUUID
 
-	err := h.service.Update(r.Context(), updatedCategory)
+	if err := updatedCategory.Validate(); err != nil {
+		return err
+	}
+
+	err = h.service.Update(r.Context(), updatedCategory)
 	if err != nil {
 		return err
 	}
@@ -214,12 +283,19 @@ func (h *categoryHandler) PartiallyUpdateCategory(w http.ResponseWriter, r *http
 
 // DeleteCategory
 // @Summary 	Delete category
-// @Description Delete category
+// @Description Delete category. Category with subcategories can not be deleted. Category with operations
+// @Description is deleted only if operations are reassigned to another category of the same user and type
+// @Description or deleted with cascade, in the same transaction as the category. Deleted category can be
+// @Description restored until it is purged after the retention period
 // @Tags 		Category
-// @Param 		uuid 	path 	 string 	true  "Category's uuid"
+// @Security 	BearerAuth
+// @Param 		uuid 		path 	 string 	true  "Category's uuid"
+// @Param 		reassign_to query 	 string 	false "Category's uuid to move operations to"
+// @Param 		cascade 	query 	 bool 		false "Delete operations of the category"
 // @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Category has subcategories or operations"
 // @Failure 	404 	{object} apperror.AppError "Category is not found"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router /categories/one [delete]
 func (h *categoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) error {
@@ -227,13 +303,28 @@ func (h *categoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request)
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	categoryUUID := params.ByName("uuid")
-	if categoryUUID == "" {
-		return apperror.BadRequestError("category uuid must not be empty")
+	categoryUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	query := r.URL.Query()
+	deleteDTO := dto.DeleteCategoryDTO{
+		UUID:       categoryUUID,
+		ReassignTo: query.Get("reassign_to"),
+	}
+	if cascade := query.Get("cascade"); cascade != "" {
+		var err error
+		if deleteDTO.Cascade, err = strconv.ParseBool(cascade); err != nil {
+			return apperror.FieldValidationError("cascade", apperror.CodeInvalid, "cascade must be true or false")
+		}
 	}
 
-	err := h.service.Delete(r.Context(), categoryUUID)
+	if err := deleteDTO.Validate(); err != nil {
+		return err
+	}
+
+	err = h.service.Delete(r.Context(), deleteDTO)
 	if err != nil {
 		return err
 	}
@@ -242,3 +333,37 @@ func (h *categoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request)
 	h.logger.Info("Delete category successfully")
 	return nil
 }
+
+// RestoreCategory
+// @Summary 	Restore category
+// @Description Restore deleted category together with operations deleted with it by cascade.
+// @Description Subcategory can be restored only if its parent is not deleted
+// @Tags 		Category
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Category's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Parent category is deleted"
+// @Failure 	404 	{object} apperror.AppError "Deleted category is not found"
+// @Failure 	409 	{object} apperror.AppError "Category with the same name and type already exists"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /categories/one/{uuid}/restore [post]
+func (h *categoryHandler) RestoreCategory(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Restore category")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	categoryUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(r.Context(), categoryUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Restore category successfully")
+	return nil
+}
@@ -0,0 +1,205 @@
+package controller
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"io"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/utils"
+	"strings"
+)
+
+const (
+	exchangeRateURL       = "/api/exchange-rates"
+	exchangeRateImportURL = "/api/exchange-rates/import"
+)
+
+type ExchangeRateService interface {
+	Save(ctx context.Context, dtos []dto.CreateExchangeRateDTO) error
+	Get(ctx context.Context, dto dto.GetExchangeRateDTO) (entity.ExchangeRate, error)
+}
+
+type exchangeRateHandler struct {
+	service ExchangeRateService
+	logger  *logging.Logger
+}
+
+func NewExchangeRateHandler(service ExchangeRateService, logger *logging.Logger) Handler {
+	return &exchangeRateHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *exchangeRateHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodPost, exchangeRateURL, apperror.Middleware(h.SaveExchangeRates))
+	router.HandlerFunc(http.MethodPost, exchangeRateImportURL, apperror.Middleware(h.ImportExchangeRates))
+	router.HandlerFunc(http.MethodGet, exchangeRateURL, apperror.Middleware(h.GetExchangeRate))
+}
+
+// SaveExchangeRates
+// @Summary 	Save exchange rates
+// @Description Creates or replaces exchange rates, requires admin role
+// @Tags 		Exchange rate
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		input	body 	 []dto.CreateExchangeRateDTO	true	"Exchange rates"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	403 	{object} apperror.AppError "User is not an administrator"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /exchange-rates [post]
+func (h *exchangeRateHandler) SaveExchangeRates(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Save exchange rates")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	var rates dto.CreateExchangeRatesDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&rates); err != nil {
+		if errors.Is(err, types.ErrInvalidRate) {
+			return apperror.ValidationError(err.Error())
+		}
+		return apperror.ValidationError("invalid JSON body")
+	}
+
+	if err := rates.Validate(); err != nil {
+		return err
+	}
+
+	err := h.service.Save(r.Context(), rates)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Save exchange rates successfully")
+	return nil
+}
+
+// ImportExchangeRates
+// @Summary 	Import exchange rates
+// @Description Creates or replaces exchange rates from CSV with columns base,quote,date,rate. Header row is optional. Requires admin role
+// @Tags 		Exchange rate
+// @Security 	BearerAuth
+// @Accept		text/csv
+// @Param 		input	body 	 string	true	"CSV with exchange rates"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	403 	{object} apperror.AppError "User is not an administrator"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /exchange-rates/import [post]
+func (h *exchangeRateHandler) ImportExchangeRates(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Import exchange rates")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	reader := csv.NewReader(r.Body)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	rates := make(dto.CreateExchangeRatesDTO, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return apperror.ValidationError(fmt.Sprintf("invalid CSV: %s", err))
+		}
+		if line == 1 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+
+		rate, err := types.ParseRate(record[3])
+		if err != nil {
+			return apperror.ValidationError(fmt.Sprintf("line %d: %s", line, err))
+		}
+		rates = append(rates, dto.CreateExchangeRateDTO{
+			Base:  types.Currency(strings.ToUpper(record[0])),
+			Quote: types.Currency(strings.ToUpper(record[1])),
+			Date:  record[2],
+			Rate:  rate,
+		})
+	}
+
+	if err := rates.Validate(); err != nil {
+		return err
+	}
+
+	err := h.service.Save(r.Context(), rates)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Import exchange rates successfully")
+	return nil
+}
+
+// GetExchangeRate
+// @Summary 	Get exchange rate
+// @Description Get the latest known exchange rate on the date
+// @Tags 		Exchange rate
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		base 	query 	 string 	true   "Base currency"
+// @Param 		quote 	query 	 string 	true   "Quote currency"
+// @Param 		date 	query 	 string 	false  "Date time (RFC 3339), now by default"
+// @Success 	200		{object} entity.ExchangeRate "Exchange rate"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/exchange-rates	[get]
+func (h *exchangeRateHandler) GetExchangeRate(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get exchange rate")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	rateDTO := dto.GetExchangeRateDTO{
+		Base:  types.Currency(query.Get("base")),
+		Quote: types.Currency(query.Get("quote")),
+	}
+
+	var errs apperror.FieldErrors
+	rateDTO.Date = parseTimeParam(query, "date", &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	if err := rateDTO.Validate(); err != nil {
+		return err
+	}
+
+	rate, err := h.service.Get(r.Context(), rateDTO)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(rate)
+	if err != nil {
+		return fmt.Errorf("failed to marshal exchange rate: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get exchange rate successfully")
+	return nil
+}
@@ -9,20 +9,29 @@ import (
 	"operation-service/internal/apperror"
 	"operation-service/internal/controller/dto"
 	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
 	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
 	"operation-service/pkg/utils"
 )
 
 const (
-	operationURL     = "/api/operations"
-	operationByIdURL = "/api/operations/one/:uuid"
+	operationURL        = "/api/operations"
+	operationByIdURL    = "/api/operations/one/:uuid"
+	balanceURL          = "/api/operations/balance"
+	operationRestoreURL = "/api/operations/one/:uuid/restore"
+	operationHistoryURL = "/api/operations/one/:uuid/history"
 )
 
 type OperationService interface {
 	Create(ctx context.Context, dto dto.CreateOperationDTO) (string, error)
 	GetByUUID(ctx context.Context, uuid string) (entity.Operation, error)
+	GetByFilter(ctx context.Context, dto dto.FindOperationsDTO) (pagination.Page[entity.Operation], error)
+	GetBalance(ctx context.Context, dto dto.GetBalanceDTO) ([]entity.Balance, error)
 	Update(ctx context.Context, dto dto.UpdateOperationDTO) error
 	Delete(ctx context.Context, uuid string) error
+	Restore(ctx context.Context, uuid string) error
+	GetHistory(ctx context.Context, uuid string, page pagination.Params) (pagination.Page[entity.AuditRecord], error)
 }
 
 type operationHandler struct {
@@ -39,20 +48,25 @@ func NewOperationHandler(service OperationService, logger *logging.Logger) Handl
 
 func (h *operationHandler) Register(router *httprouter.Router) {
 	router.HandlerFunc(http.MethodPost, operationURL, apperror.Middleware(h.CreateOperation))
+	router.HandlerFunc(http.MethodGet, operationURL, apperror.Middleware(h.GetOperations))
 	router.HandlerFunc(http.MethodGet, operationByIdURL, apperror.Middleware(h.GetOperationByUUID))
+	router.HandlerFunc(http.MethodGet, balanceURL, apperror.Middleware(h.GetBalance))
 	router.HandlerFunc(http.MethodPatch, operationByIdURL, apperror.Middleware(h.PartiallyUpdateOperation))
 	router.HandlerFunc(http.MethodDelete, operationByIdURL, apperror.Middleware(h.DeleteOperation))
+	router.HandlerFunc(http.MethodPost, operationRestoreURL, apperror.Middleware(h.RestoreOperation))
+	router.HandlerFunc(http.MethodGet, operationHistoryURL, apperror.Middleware(h.GetOperationHistory))
 }
 
 // CreateOperation
 // @Summary 	Create operation
 // @Description Creates new operation
 // @Tags 		Operation
+// @Security 	BearerAuth
 // @Accept		json
 // @Param 		input	body 	 dto.CreateOperationDTO	true	"Operation's data"
 // @Success 	201
 // @Failure 	400 	{object} apperror.AppError "Validation error"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router /operations [post]
 func (h *operationHandler) CreateOperation(w http.ResponseWriter, r *http.Request) error {
@@ -64,11 +78,11 @@ func (h *operationHandler) CreateOperation(w http.ResponseWriter, r *http.Reques
 
 	if err := json.NewDecoder(r.Body).Decode(&createdOperation); err != nil {
 		h.logger.Error(err)
-		return apperror.BadRequestError("invalid JSON body")
+		return decodeError(err)
 	}
 
-	if createdOperation.CategoryUUID == "" || createdOperation.MoneySum == 0 {
-		return apperror.BadRequestError("missing required fields")
+	if err := createdOperation.Validate(); err != nil {
+		return err
 	}
 
 	operationUUID, err := h.service.Create(r.Context(), createdOperation)
@@ -87,11 +101,13 @@ func (h *operationHandler) CreateOperation(w http.ResponseWriter, r *http.Reques
 // @Summary 	Get operation by uuid
 // @Description Get operation by uuid
 // @Tags 		Operation
+// @Security 	BearerAuth
 // @Produce 	json
 // @Param 		uuid 	path 	 string 	true   "Operation's uuid"
 // @Success 	200		{object} entity.Operation  "Operation"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
 // @Failure 	404 	{object} apperror.AppError "Operation not found"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router 		/operations/one/	[get]
 func (h *operationHandler) GetOperationByUUID(w http.ResponseWriter, r *http.Request) error {
@@ -99,10 +115,9 @@ func (h *operationHandler) GetOperationByUUID(w http.ResponseWriter, r *http.Req
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	operationUUID := params.ByName("uuid")
-	if operationUUID == "" {
-		return apperror.BadRequestError("operation uuid must not be empty")
+	operationUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
 	}
 
 	operation, err := h.service.GetByUUID(r.Context(), operationUUID)
@@ -126,16 +141,146 @@ func (h *operationHandler) GetOperationByUUID(w http.ResponseWriter, r *http.Req
 	return nil
 }
 
+// GetOperations
+// @Summary 	Get operations
+// @Description Get list of operations matching filters. Only operations of the authenticated user are returned
+// @Tags 		Operation
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 		query 	 string 	false  "User's uuid, the authenticated user by default"
+// @Param 		category_uuid 	query 	 []string 	false  "Category's uuid" collectionFormat(multi)
+// @Param 		account_uuid 	query 	 string 	false  "Account's uuid"
+// @Param 		date_from 		query 	 string 	false  "Lower bound of operation date (RFC 3339)"
+// @Param 		date_to 		query 	 string 	false  "Upper bound of operation date (RFC 3339)"
+// @Param 		min_sum 		query 	 string 	false  "Minimal absolute money sum"
+// @Param 		max_sum 		query 	 string 	false  "Maximal absolute money sum"
+// @Param 		currency 		query 	 string 	false  "ISO 4217 currency code"
+// @Param 		description 	query 	 string 	false  "Substring of description"
+// @Param 		limit 			query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 			query 	 string 	false  "Cursor of the next page"
+// @Success 	200		{object} pagination.Page[entity.Operation] "Page of operations"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/operations	[get]
+func (h *operationHandler) GetOperations(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get operations")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	filter := dto.FindOperationsDTO{
+		UserUUID:      query.Get("user_uuid"),
+		CategoryUUIDs: query["category_uuid"],
+		AccountUUID:   query.Get("account_uuid"),
+		Currency:      types.Currency(query.Get("currency")),
+		Description:   query.Get("description"),
+	}
+
+	var errs apperror.FieldErrors
+	filter.DateFrom = parseTimeParam(query, "date_from", &errs)
+	filter.DateTo = parseTimeParam(query, "date_to", &errs)
+	filter.MinSum = parseMoneyParam(query, "min_sum", &errs)
+	filter.MaxSum = parseMoneyParam(query, "max_sum", &errs)
+	filter.Page = parsePageParams(query, &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	operations, err := h.service.GetByFilter(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(operations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal operations: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get operations successfully")
+	return nil
+}
+
+// GetBalance
+// @Summary 	Get balance
+// @Description Get total income, total expense and net balance of user's operations per currency
+// @Tags 		Operation
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	query 	 string 	false  "User's uuid, the authenticated user by default"
+// @Param 		date_from 	query 	 string 	false  "Lower bound of operation date (RFC 3339)"
+// @Param 		date_to 	query 	 string 	false  "Upper bound of operation date (RFC 3339)"
+// @Param 		currency 	query 	 string 	false  "Currency to convert all sums to at the rates of operation dates"
+// @Success 	200		{object} []entity.Balance 	"Balance per currency"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/operations/balance	[get]
+func (h *operationHandler) GetBalance(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get balance")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	balanceDTO := dto.GetBalanceDTO{
+		UserUUID: query.Get("user_uuid"),
+		Currency: types.Currency(query.Get("currency")),
+	}
+
+	var errs apperror.FieldErrors
+	balanceDTO.DateFrom = parseTimeParam(query, "date_from", &errs)
+	balanceDTO.DateTo = parseTimeParam(query, "date_to", &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	if err := balanceDTO.Validate(); err != nil {
+		return err
+	}
+
+	balances, err := h.service.GetBalance(r.Context(), balanceDTO)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(balances)
+	if err != nil {
+		return fmt.Errorf("failed to marshal balance: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get balance successfully")
+	return nil
+}
+
 // PartiallyUpdateOperation
 // @Summary 	Update Operation
 // @Description Update Operation
 // @Tags 		Operation
+// @Security 	BearerAuth
 // @Accept		json
 // @Param 		uuid 		path 	 string 				true  "Operation's uuid"
 // @Param 		input 		body 	 dto.UpdateOperationDTO true  "Operation's data"
 // @Success 	204
 // @Failure 	400 	{object} apperror.AppError "Validation error"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router /operations/one [patch]
 func (h *operationHandler) PartiallyUpdateOperation(w http.ResponseWriter, r *http.Request) error {
@@ -143,21 +288,24 @@ func (h *operationHandler) PartiallyUpdateOperation(w http.ResponseWriter, r *ht
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	operationUUID := params.ByName("uuid")
-	if operationUUID == "" {
-		return apperror.BadRequestError("operation uuid must not be empty")
+	operationUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
 	}
 
 	var updatedOperation dto.UpdateOperationDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&updatedOperation); err != nil {
-		return apperror.BadRequestError("invalid JSON body")
+		return decodeError(err)
 	}
 
 	updatedOperation.UUID = operationUUID
 
-	err := h.service.Update(r.Context(), updatedOperation)
+	if err := updatedOperation.Validate(); err != nil {
+		return err
+	}
+
+	err = h.service.Update(r.Context(), updatedOperation)
 	if err != nil {
 		return err
 	}
@@ -169,12 +317,14 @@ func (h *operationHandler) PartiallyUpdateOperation(w http.ResponseWriter, r *ht
 
 // DeleteOperation
 // @Summary 	Delete operation
-// @Description Delete operation
+// @Description Delete operation. Deleted operation can be restored until it is purged after the retention period
 // @Tags 		Operation
+// @Security 	BearerAuth
 // @Param 		uuid 	path 	 string 	true  "Operation's uuid"
 // @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
 // @Failure 	404 	{object} apperror.AppError "Operation is not found"
-// @Failure 	418 	{object} apperror.AppError "Something wrong with application logic"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
 // @Failure 	500 	{object} apperror.AppError "Internal server error"
 // @Router /operations/one [delete]
 func (h *operationHandler) DeleteOperation(w http.ResponseWriter, r *http.Request) error {
@@ -182,13 +332,12 @@ func (h *operationHandler) DeleteOperation(w http.ResponseWriter, r *http.Reques
 	defer utils.CloseBody(h.logger, r.Body)
 	w.Header().Set("Content-Type", "application/json")
 
-	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
-	operationUUID := params.ByName("uuid")
-	if operationUUID == "" {
-		return apperror.BadRequestError("operation uuid must not be empty")
+	operationUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
 	}
 
-	err := h.service.Delete(r.Context(), operationUUID)
+	err = h.service.Delete(r.Context(), operationUUID)
 	if err != nil {
 		return err
 	}
@@ -197,3 +346,88 @@ func (h *operationHandler) DeleteOperation(w http.ResponseWriter, r *http.Reques
 	h.logger.Info("Delete operation successfully")
 	return nil
 }
+
+// RestoreOperation
+// @Summary 	Restore operation
+// @Description Restore deleted operation. Operation of a deleted category can be restored only with the category,
+// @Description operation of a transfer only with the transfer
+// @Tags 		Operation
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Operation's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Category of the operation is deleted"
+// @Failure 	404 	{object} apperror.AppError "Deleted operation is not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /operations/one/{uuid}/restore [post]
+func (h *operationHandler) RestoreOperation(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Restore operation")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	operationUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(r.Context(), operationUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Restore operation successfully")
+	return nil
+}
+
+// GetOperationHistory
+// @Summary 	Get operation history
+// @Description Get changes of operation starting from the latest one, with the actor and the request id of
+// @Description each change. History of deleted operations is kept
+// @Tags 		Operation
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Operation's uuid"
+// @Param 		limit 	query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 	query 	 string 	false  "Cursor of the next page"
+// @Success 	200		{object} pagination.Page[entity.AuditRecord] "Page of changes"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /operations/one/{uuid}/history [get]
+func (h *operationHandler) GetOperationHistory(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get operation history")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	operationUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parseTimePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	history, err := h.service.GetHistory(r.Context(), operationUUID, page)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("failed to marshal operation history: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get operation history successfully")
+	return nil
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"net/url"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/internal/validation"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+// uuidParam returns the path parameter which must be uuid.
+func uuidParam(r *http.Request, name string) (string, error) {
+	params := r.Context().Value(httprouter.ParamsKey).(httprouter.Params)
+	value := params.ByName(name)
+
+	var errs apperror.FieldErrors
+	validation.RequiredUUID(&errs, name, value)
+	return value, errs.Err()
+}
+
+// parseTimeParam parses RFC 3339 query parameter, an invalid value is added to errs.
+func parseTimeParam(query url.Values, name string, errs *apperror.FieldErrors) *time.Time {
+	value := query.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		errs.Add(name, apperror.CodeInvalid, fmt.Sprintf("%s must be RFC 3339 date time", name))
+		return nil
+	}
+	return &t
+}
+
+// parseMoneyParam parses money query parameter, an invalid value is added to errs.
+func parseMoneyParam(query url.Values, name string, errs *apperror.FieldErrors) *types.Money {
+	value := query.Get(name)
+	if value == "" {
+		return nil
+	}
+
+	m, err := types.ParseMoney(value)
+	if err != nil {
+		errs.Add(name, apperror.CodeInvalid, fmt.Sprintf("%s: %s", name, err))
+		return nil
+	}
+	return &m
+}
+
+// parsePageParams parses limit and cursor query parameters, invalid values are added to errs.
+func parsePageParams(query url.Values, errs *apperror.FieldErrors) pagination.Params {
+	page, err := pagination.NewParams(query.Get("limit"), "")
+	if err != nil {
+		errs.Add("limit", apperror.CodeOutOfRange, err.Error())
+	}
+
+	if cursor := query.Get("cursor"); cursor != "" {
+		page.After, err = pagination.DecodeCursor(cursor)
+		// the cursor comes from the client, so the uuid in it is checked as any other uuid
+		if err != nil || !validation.IsUUID(page.After.UUID) {
+			errs.Add("cursor", apperror.CodeInvalid, pagination.ErrInvalidCursor.Error())
+			page.After = nil
+		}
+	}
+	return page
+}
+
+// parseTimePageParams parses page parameters of lists ordered by time, whose cursor must carry
+// the time of the last item.
+func parseTimePageParams(query url.Values, errs *apperror.FieldErrors) pagination.Params {
+	page := parsePageParams(query, errs)
+	if page.After != nil && page.After.DateTime == nil {
+		errs.Add("cursor", apperror.CodeInvalid, pagination.ErrInvalidCursor.Error())
+		page.After = nil
+	}
+	return page
+}
+
+// decodeError converts JSON body decoding error to a client error.
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apperror.FieldValidationError(typeErr.Field, apperror.CodeInvalid,
+			fmt.Sprintf("%s must not be %s", typeErr.Field, typeErr.Value))
+	}
+	var timeErr *time.ParseError
+	if errors.As(err, &timeErr) {
+		return apperror.ValidationError("date time must be RFC 3339 with time zone")
+	}
+	if errors.Is(err, types.ErrInvalidMoney) || errors.Is(err, types.ErrMoneyOutOfRange) {
+		return apperror.ValidationError(err.Error())
+	}
+	return apperror.ValidationError("invalid JSON body")
+}
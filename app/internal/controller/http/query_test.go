@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"net/url"
+	"operation-service/internal/apperror"
+	"operation-service/pkg/pagination"
+	"testing"
+	"time"
+)
+
+func TestParseTimePageParams(t *testing.T) {
+	const uuid = "6b7c6a0e-2f1d-4c3b-9a8e-1d2c3b4a5f60"
+	dateTime := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		cursor     string
+		wantAfter  bool
+		wantErrors []string
+	}{
+		{name: "first page"},
+		{name: "cursor with time", cursor: pagination.Cursor{DateTime: &dateTime, UUID: uuid}.Encode(),
+			wantAfter: true},
+		{name: "cursor without time", cursor: pagination.Cursor{UUID: uuid}.Encode(),
+			wantErrors: []string{"cursor"}},
+		{name: "cursor with invalid uuid", cursor: pagination.Cursor{DateTime: &dateTime, UUID: "1"}.Encode(),
+			wantErrors: []string{"cursor"}},
+		{name: "not a cursor", cursor: "!", wantErrors: []string{"cursor"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := url.Values{}
+			if tt.cursor != "" {
+				query.Set("cursor", tt.cursor)
+			}
+
+			var errs apperror.FieldErrors
+			page := parseTimePageParams(query, &errs)
+			if (page.After != nil) != tt.wantAfter {
+				t.Errorf("page.After = %v, want set %t", page.After, tt.wantAfter)
+			}
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("errors = %v, want errors of %v", errs, tt.wantErrors)
+			}
+			for i, field := range tt.wantErrors {
+				if errs[i].Field != field || errs[i].Code != apperror.CodeInvalid {
+					t.Errorf("error %d = %s %s, want %s %s", i, errs[i].Field, errs[i].Code, field,
+						apperror.CodeInvalid)
+				}
+			}
+		})
+	}
+}
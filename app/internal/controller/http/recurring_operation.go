@@ -0,0 +1,287 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/utils"
+)
+
+const (
+	recurringOperationURL         = "/api/recurring-operations"
+	recurringOperationByIdURL     = "/api/recurring-operations/one/:uuid"
+	recurringOperationPauseURL    = "/api/recurring-operations/one/:uuid/pause"
+	recurringOperationResumeURL   = "/api/recurring-operations/one/:uuid/resume"
+	recurringOperationByUserIdURL = "/api/recurring-operations/user_uuid/:user_uuid"
+)
+
+type RecurringOperationService interface {
+	Create(ctx context.Context, dto dto.CreateRecurringOperationDTO) (string, error)
+	GetByUUID(ctx context.Context, uuid string) (entity.RecurringOperation, error)
+	GetByUserUUID(ctx context.Context, uuid string,
+		page pagination.Params) (pagination.Page[entity.RecurringOperation], error)
+	Pause(ctx context.Context, uuid string) error
+	Resume(ctx context.Context, uuid string) error
+	Delete(ctx context.Context, uuid string) error
+}
+
+type recurringOperationHandler struct {
+	service RecurringOperationService
+	logger  *logging.Logger
+}
+
+func NewRecurringOperationHandler(service RecurringOperationService, logger *logging.Logger) Handler {
+	return &recurringOperationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *recurringOperationHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodPost, recurringOperationURL, apperror.Middleware(h.CreateRecurringOperation))
+	router.HandlerFunc(http.MethodGet, recurringOperationByIdURL, apperror.Middleware(h.GetRecurringOperationByUUID))
+	router.HandlerFunc(http.MethodGet, recurringOperationByUserIdURL,
+		apperror.Middleware(h.GetRecurringOperationsByUserUUID))
+	router.HandlerFunc(http.MethodPost, recurringOperationPauseURL, apperror.Middleware(h.PauseRecurringOperation))
+	router.HandlerFunc(http.MethodPost, recurringOperationResumeURL, apperror.Middleware(h.ResumeRecurringOperation))
+	router.HandlerFunc(http.MethodDelete, recurringOperationByIdURL, apperror.Middleware(h.DeleteRecurringOperation))
+}
+
+// CreateRecurringOperation
+// @Summary 	Create recurring operation
+// @Description Creates template of operation repeated every interval of days, weeks, months or years
+// @Description from start date until end date or count of occurrences. Operations are created by scheduler
+// @Description when they are due, including ones between past start date and now
+// @Tags 		Recurring operation
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		input	body 	 dto.CreateRecurringOperationDTO	true	"Recurring operation data"
+// @Success 	201
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Category or account not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /recurring-operations [post]
+func (h *recurringOperationHandler) CreateRecurringOperation(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Create recurring operation")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	var createdRecurring dto.CreateRecurringOperationDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&createdRecurring); err != nil {
+		return decodeError(err)
+	}
+
+	if err := createdRecurring.Validate(); err != nil {
+		return err
+	}
+
+	recurringUUID, err := h.service.Create(r.Context(), createdRecurring)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", recurringOperationURL, recurringUUID))
+	w.WriteHeader(http.StatusCreated)
+
+	h.logger.Info("Create recurring operation successfully")
+	return nil
+}
+
+// GetRecurringOperationByUUID
+// @Summary 	Get recurring operation by uuid
+// @Description Get recurring operation by uuid
+// @Tags 		Recurring operation
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Recurring operation's uuid"
+// @Success 	200		{object} entity.RecurringOperation "Recurring operation"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Recurring operation not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/recurring-operations/one/	[get]
+func (h *recurringOperationHandler) GetRecurringOperationByUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get recurring operation by uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	recurringUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	recurring, err := h.service.GetByUUID(r.Context(), recurringUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(recurring)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recurring operation: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get recurring operation by uuid successfully")
+	return nil
+}
+
+// GetRecurringOperationsByUserUUID
+// @Summary 	Get recurring operations by user's uuid
+// @Description Get list of recurring operations belonging to user
+// @Tags 		Recurring operation
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	path 	 string 	true   "User's uuid"
+// @Param 		limit 		query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 		query 	 string 	false  "Cursor of the next page"
+// @Success 	200			{object} pagination.Page[entity.RecurringOperation] "Page of recurring operations"
+// @Failure 	400 		{object} apperror.AppError "Validation error"
+// @Failure 	401 		{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 		{object} apperror.AppError "Internal server error"
+// @Router 		/recurring-operations/user_uuid/	[get]
+func (h *recurringOperationHandler) GetRecurringOperationsByUserUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get recurring operations by user's uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	userUUID, err := uuidParam(r, "user_uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parsePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	recurringOperations, err := h.service.GetByUserUUID(r.Context(), userUUID, page)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(recurringOperations)
+	if err != nil {
+		return fmt.Errorf("failed to marshal recurring operations: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get recurring operations by user's uuid successfully")
+	return nil
+}
+
+// PauseRecurringOperation
+// @Summary 	Pause recurring operation
+// @Description Stops creating operations of recurring operation until it is resumed
+// @Tags 		Recurring operation
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Recurring operation's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Recurring operation not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /recurring-operations/one/{uuid}/pause [post]
+func (h *recurringOperationHandler) PauseRecurringOperation(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Pause recurring operation")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	recurringUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Pause(r.Context(), recurringUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Pause recurring operation successfully")
+	return nil
+}
+
+// ResumeRecurringOperation
+// @Summary 	Resume recurring operation
+// @Description Resumes paused recurring operation. Occurrences missed during the pause are skipped
+// @Tags 		Recurring operation
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Recurring operation's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Recurring operation not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /recurring-operations/one/{uuid}/resume [post]
+func (h *recurringOperationHandler) ResumeRecurringOperation(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Resume recurring operation")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	recurringUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Resume(r.Context(), recurringUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Resume recurring operation successfully")
+	return nil
+}
+
+// DeleteRecurringOperation
+// @Summary 	Delete recurring operation
+// @Description Delete recurring operation. Operations already created by it are kept
+// @Tags 		Recurring operation
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Recurring operation's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /recurring-operations/one [delete]
+func (h *recurringOperationHandler) DeleteRecurringOperation(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Delete recurring operation")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	recurringUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(r.Context(), recurringUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Delete recurring operation successfully")
+	return nil
+}
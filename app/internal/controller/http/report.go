@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/utils"
+	"strconv"
+)
+
+const (
+	reportByCategoryURL = "/api/reports/by-category"
+)
+
+type ReportService interface {
+	GetByCategory(ctx context.Context, dto dto.GetCategoryReportDTO) ([]entity.CategoryReport, error)
+}
+
+type reportHandler struct {
+	service ReportService
+	logger  *logging.Logger
+}
+
+func NewReportHandler(service ReportService, logger *logging.Logger) Handler {
+	return &reportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *reportHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodGet, reportByCategoryURL, apperror.Middleware(h.GetReportByCategory))
+}
+
+// GetReportByCategory
+// @Summary 	Get report by category
+// @Description Get income and expense sums of user's operations grouped by category, time bucket and currency
+// @Tags 		Report
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	query 	 string 	false  "User's uuid, the authenticated user by default"
+// @Param 		date_from 	query 	 string 	false  "Lower bound of operation date (RFC 3339)"
+// @Param 		date_to 	query 	 string 	false  "Upper bound of operation date (RFC 3339)"
+// @Param 		bucket 		query 	 string 	false  "Time bucket" Enums(day, week, month) default(month)
+// @Param 		currency 	query 	 string 	false  "Currency to convert all sums to at the rates of operation dates"
+// @Param 		rollup 		query 	 bool 		false  "Add sums of subcategories to their parent categories"
+// @Success 	200		{object} []entity.CategoryReport "Report"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/reports/by-category	[get]
+func (h *reportHandler) GetReportByCategory(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get report by category")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	reportDTO := dto.GetCategoryReportDTO{
+		UserUUID: query.Get("user_uuid"),
+		Bucket:   types.ReportBucket(query.Get("bucket")),
+		Currency: types.Currency(query.Get("currency")),
+	}
+
+	var errs apperror.FieldErrors
+	if rollup := query.Get("rollup"); rollup != "" {
+		var err error
+		if reportDTO.Rollup, err = strconv.ParseBool(rollup); err != nil {
+			errs.Add("rollup", apperror.CodeInvalid, "rollup must be true or false")
+		}
+	}
+	reportDTO.DateFrom = parseTimeParam(query, "date_from", &errs)
+	reportDTO.DateTo = parseTimeParam(query, "date_to", &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	if err := reportDTO.Validate(); err != nil {
+		return err
+	}
+
+	report, err := h.service.GetByCategory(r.Context(), reportDTO)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get report by category successfully")
+	return nil
+}
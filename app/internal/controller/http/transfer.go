@@ -0,0 +1,244 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/utils"
+)
+
+const (
+	transferURL        = "/api/transfers"
+	transferByIdURL    = "/api/transfers/one/:uuid"
+	transferRestoreURL = "/api/transfers/one/:uuid/restore"
+)
+
+type TransferService interface {
+	Create(ctx context.Context, dto dto.CreateTransferDTO) (string, error)
+	GetByUUID(ctx context.Context, uuid string) (entity.Transfer, error)
+	Update(ctx context.Context, dto dto.UpdateTransferDTO) error
+	Delete(ctx context.Context, uuid string) error
+	Restore(ctx context.Context, uuid string) error
+}
+
+type transferHandler struct {
+	service TransferService
+	logger  *logging.Logger
+}
+
+func NewTransferHandler(service TransferService, logger *logging.Logger) Handler {
+	return &transferHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *transferHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodPost, transferURL, apperror.Middleware(h.CreateTransfer))
+	router.HandlerFunc(http.MethodGet, transferByIdURL, apperror.Middleware(h.GetTransferByUUID))
+	router.HandlerFunc(http.MethodPatch, transferByIdURL, apperror.Middleware(h.PartiallyUpdateTransfer))
+	router.HandlerFunc(http.MethodDelete, transferByIdURL, apperror.Middleware(h.DeleteTransfer))
+	router.HandlerFunc(http.MethodPost, transferRestoreURL, apperror.Middleware(h.RestoreTransfer))
+}
+
+// CreateTransfer
+// @Summary 	Create transfer
+// @Description Moves money between two accounts of the same user and currency.
+// @Description Transfer is stored with a debit operation on the source account and a credit operation
+// @Description on the target account, which are not counted in balance and reports
+// @Tags 		Transfer
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		input	body 	 dto.CreateTransferDTO	true	"Transfer data"
+// @Success 	201
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Account not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /transfers [post]
+func (h *transferHandler) CreateTransfer(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Create transfer")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	var createdTransfer dto.CreateTransferDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&createdTransfer); err != nil {
+		return decodeError(err)
+	}
+
+	if err := createdTransfer.Validate(); err != nil {
+		return err
+	}
+
+	transferUUID, err := h.service.Create(r.Context(), createdTransfer)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", transferURL, transferUUID))
+	w.WriteHeader(http.StatusCreated)
+
+	h.logger.Info("Create transfer successfully")
+	return nil
+}
+
+// GetTransferByUUID
+// @Summary 	Get transfer by uuid
+// @Description Get transfer by uuid
+// @Tags 		Transfer
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Transfer's uuid"
+// @Success 	200		{object} entity.Transfer "Transfer"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Transfer not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/transfers/one/	[get]
+func (h *transferHandler) GetTransferByUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get transfer by uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	transferUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	transfer, err := h.service.GetByUUID(r.Context(), transferUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(transfer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transfer: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get transfer by uuid successfully")
+	return nil
+}
+
+// PartiallyUpdateTransfer
+// @Summary 	Update transfer
+// @Description Update transfer together with its debit and credit operations
+// @Tags 		Transfer
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		uuid 		path 	 string 				true  "Transfer's uuid"
+// @Param 		input 		body 	 dto.UpdateTransferDTO 	true  "Transfer's data"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Transfer not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /transfers/one [patch]
+func (h *transferHandler) PartiallyUpdateTransfer(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Partially update transfer")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	transferUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	var updatedTransfer dto.UpdateTransferDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&updatedTransfer); err != nil {
+		return decodeError(err)
+	}
+
+	updatedTransfer.UUID = transferUUID
+
+	if err := updatedTransfer.Validate(); err != nil {
+		return err
+	}
+
+	err = h.service.Update(r.Context(), updatedTransfer)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Update transfer successfully")
+	return nil
+}
+
+// DeleteTransfer
+// @Summary 	Delete transfer
+// @Description Delete transfer together with its debit and credit operations. Deleted transfer can be restored
+// @Description until it is purged after the retention period
+// @Tags 		Transfer
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Transfer's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /transfers/one [delete]
+func (h *transferHandler) DeleteTransfer(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Delete transfer")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	transferUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(r.Context(), transferUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Delete transfer successfully")
+	return nil
+}
+
+// RestoreTransfer
+// @Summary 	Restore transfer
+// @Description Restore deleted transfer together with its debit and credit operations
+// @Tags 		Transfer
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Transfer's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Deleted transfer is not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /transfers/one/{uuid}/restore [post]
+func (h *transferHandler) RestoreTransfer(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Restore transfer")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	transferUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Restore(r.Context(), transferUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Restore transfer successfully")
+	return nil
+}
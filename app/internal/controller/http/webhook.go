@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/julienschmidt/httprouter"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/utils"
+)
+
+const (
+	webhookURL           = "/api/webhooks"
+	webhookByIdURL       = "/api/webhooks/one/:uuid"
+	webhookDeliveriesURL = "/api/webhooks/one/:uuid/deliveries"
+	webhookByUserIdURL   = "/api/webhooks/user_uuid/:user_uuid"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, dto dto.CreateWebhookDTO) (string, error)
+	GetByUUID(ctx context.Context, uuid string) (entity.Webhook, error)
+	GetByUserUUID(ctx context.Context, uuid string, page pagination.Params) (pagination.Page[entity.Webhook], error)
+	GetDeliveries(ctx context.Context, uuid string,
+		page pagination.Params) (pagination.Page[entity.WebhookDelivery], error)
+	Delete(ctx context.Context, uuid string) error
+}
+
+type webhookHandler struct {
+	service WebhookService
+	logger  *logging.Logger
+}
+
+func NewWebhookHandler(service WebhookService, logger *logging.Logger) Handler {
+	return &webhookHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *webhookHandler) Register(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodPost, webhookURL, apperror.Middleware(h.CreateWebhook))
+	router.HandlerFunc(http.MethodGet, webhookByIdURL, apperror.Middleware(h.GetWebhookByUUID))
+	router.HandlerFunc(http.MethodGet, webhookDeliveriesURL, apperror.Middleware(h.GetWebhookDeliveries))
+	router.HandlerFunc(http.MethodGet, webhookByUserIdURL, apperror.Middleware(h.GetWebhooksByUserUUID))
+	router.HandlerFunc(http.MethodDelete, webhookByIdURL, apperror.Middleware(h.DeleteWebhook))
+}
+
+// CreateWebhook
+// @Summary 	Create webhook
+// @Description Subscribes url to user's events. Events are posted as JSON with X-Webhook-Event,
+// @Description X-Webhook-Delivery, X-Webhook-Timestamp and X-Webhook-Signature headers. The signature is
+// @Description "sha256=" followed by hex of HMAC-SHA256 of "<timestamp>.<body>" with the secret.
+// @Description Failed deliveries are retried with exponential backoff
+// @Tags 		Webhook
+// @Security 	BearerAuth
+// @Accept		json
+// @Param 		input	body 	 dto.CreateWebhookDTO	true	"Webhook data"
+// @Success 	201
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /webhooks [post]
+func (h *webhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Create webhook")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	var createdWebhook dto.CreateWebhookDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&createdWebhook); err != nil {
+		return decodeError(err)
+	}
+
+	if err := createdWebhook.Validate(); err != nil {
+		return err
+	}
+
+	webhookUUID, err := h.service.Create(r.Context(), createdWebhook)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Location", fmt.Sprintf("%s/%s", webhookURL, webhookUUID))
+	w.WriteHeader(http.StatusCreated)
+
+	h.logger.Info("Create webhook successfully")
+	return nil
+}
+
+// GetWebhookByUUID
+// @Summary 	Get webhook by uuid
+// @Description Get webhook by uuid
+// @Tags 		Webhook
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Webhook's uuid"
+// @Success 	200		{object} entity.Webhook "Webhook"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Webhook not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/webhooks/one/	[get]
+func (h *webhookHandler) GetWebhookByUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get webhook by uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	webhookUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	webhook, err := h.service.GetByUUID(r.Context(), webhookUUID)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(webhook)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get webhook by uuid successfully")
+	return nil
+}
+
+// GetWebhookDeliveries
+// @Summary 	Get webhook deliveries
+// @Description Get log of events sent or to be sent to webhook, newest first
+// @Tags 		Webhook
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		uuid 	path 	 string 	true   "Webhook's uuid"
+// @Param 		limit 	query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 	query 	 string 	false  "Cursor of the next page"
+// @Success 	200		{object} pagination.Page[entity.WebhookDelivery] "Page of deliveries"
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	404 	{object} apperror.AppError "Webhook not found"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router 		/webhooks/one/{uuid}/deliveries	[get]
+func (h *webhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get webhook deliveries")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	webhookUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parseTimePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), webhookUUID, page)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(deliveries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook deliveries: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get webhook deliveries successfully")
+	return nil
+}
+
+// GetWebhooksByUserUUID
+// @Summary 	Get webhooks by user's uuid
+// @Description Get list of webhooks belonging to user
+// @Tags 		Webhook
+// @Security 	BearerAuth
+// @Produce 	json
+// @Param 		user_uuid 	path 	 string 	true   "User's uuid"
+// @Param 		limit 		query 	 int 		false  "Page size (1-500, default 50)"
+// @Param 		cursor 		query 	 string 	false  "Cursor of the next page"
+// @Success 	200			{object} pagination.Page[entity.Webhook] "Page of webhooks"
+// @Failure 	400 		{object} apperror.AppError "Validation error"
+// @Failure 	401 		{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 		{object} apperror.AppError "Internal server error"
+// @Router 		/webhooks/user_uuid/	[get]
+func (h *webhookHandler) GetWebhooksByUserUUID(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Get webhooks by user's uuid")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	userUUID, err := uuidParam(r, "user_uuid")
+	if err != nil {
+		return err
+	}
+
+	var errs apperror.FieldErrors
+	page := parsePageParams(r.URL.Query(), &errs)
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	webhooks, err := h.service.GetByUserUUID(r.Context(), userUUID, page)
+	if err != nil {
+		return err
+	}
+
+	var bytes []byte
+	bytes, err = json.Marshal(webhooks)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhooks: %w", err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(bytes)
+	if err != nil {
+		return err
+	}
+
+	h.logger.Info("Get webhooks by user's uuid successfully")
+	return nil
+}
+
+// DeleteWebhook
+// @Summary 	Delete webhook
+// @Description Delete webhook together with its deliveries
+// @Tags 		Webhook
+// @Security 	BearerAuth
+// @Param 		uuid 	path 	 string 	true  "Webhook's uuid"
+// @Success 	204
+// @Failure 	400 	{object} apperror.AppError "Validation error"
+// @Failure 	401 	{object} apperror.AppError "User is not authenticated"
+// @Failure 	500 	{object} apperror.AppError "Internal server error"
+// @Router /webhooks/one [delete]
+func (h *webhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) error {
+	h.logger.Info("Delete webhook")
+	defer utils.CloseBody(h.logger, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+
+	webhookUUID, err := uuidParam(r, "uuid")
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(r.Context(), webhookUUID)
+	if err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+
+	h.logger.Info("Delete webhook successfully")
+	return nil
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/pagination"
+)
+
+type Account struct {
+	UUID     string         `json:"uuid"`
+	UserUUID string         `json:"user_uuid"`
+	Name     string         `json:"name"`
+	Currency types.Currency `json:"currency" example:"USD"`
+}
+
+func (a Account) Cursor() pagination.Cursor {
+	return pagination.Cursor{UUID: a.UUID}
+}
+
+func NewAccount(dto dto.CreateAccountDTO) *Account {
+	return &Account{
+		UserUUID: dto.UserUUID,
+		Name:     dto.Name,
+		Currency: dto.Currency,
+	}
+}
+
+func UpdatedAccount(existing Account, dto dto.UpdateAccountDTO) *Account {
+	updAccount := new(Account)
+
+	updAccount.UUID = dto.UUID
+
+	if dto.Name != "" {
+		updAccount.Name = dto.Name
+	} else {
+		updAccount.Name = existing.Name
+	}
+
+	updAccount.UserUUID = existing.UserUUID
+	updAccount.Currency = existing.Currency
+
+	return updAccount
+}
+
+type AccountBalance struct {
+	AccountUUID string         `json:"account_uuid"`
+	Currency    types.Currency `json:"currency" example:"USD"`
+	Balance     types.Money    `json:"balance" swaggertype:"string" example:"1250.00"`
+}
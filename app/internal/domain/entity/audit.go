@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+const (
+	OperationAuditEntity = "operation"
+	CategoryAuditEntity  = "category"
+
+	CreateAuditAction  = "create"
+	UpdateAuditAction  = "update"
+	DeleteAuditAction  = "delete"
+	RestoreAuditAction = "restore"
+)
+
+// FieldChange holds JSON values of a field before and after the change, null for a missing side.
+type FieldChange struct {
+	Before json.RawMessage `json:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+// AuditRecord is a change of an operation or a category made by Actor within the request RequestID.
+type AuditRecord struct {
+	UUID       string                 `json:"uuid"`
+	EntityType string                 `json:"entity_type"`
+	EntityUUID string                 `json:"entity_uuid"`
+	Action     string                 `json:"action"`
+	Actor      string                 `json:"actor,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Changes    map[string]FieldChange `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+func (a AuditRecord) Cursor() pagination.Cursor {
+	return pagination.Cursor{DateTime: &a.CreatedAt, UUID: a.UUID}
+}
+
+// NewAuditRecord records the fields of the entity which differ between before and after.
+// Before is nil for created entities and after is nil for deleted ones.
+func NewAuditRecord(entityType, entityUUID, action string, before, after any) (*AuditRecord, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]FieldChange)
+	for field, value := range beforeFields {
+		if afterValue, ok := afterFields[field]; !ok || !bytes.Equal(value, afterValue) {
+			changes[field] = FieldChange{Before: value, After: afterFields[field]}
+		}
+	}
+	for field, value := range afterFields {
+		if _, ok := beforeFields[field]; !ok {
+			changes[field] = FieldChange{After: value}
+		}
+	}
+
+	return &AuditRecord{
+		EntityType: entityType,
+		EntityUUID: entityUUID,
+		Action:     action,
+		Changes:    changes,
+	}, nil
+}
+
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if v == nil {
+		return fields, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
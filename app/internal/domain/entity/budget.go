@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+// Budget limits expenses of a category within every period. With Rollover the amount left
+// (or overspent) in the previous period is added to the limit of the current one.
+type Budget struct {
+	UUID         string             `json:"uuid"`
+	UserUUID     string             `json:"user_uuid"`
+	CategoryUUID string             `json:"category_uuid"`
+	Period       types.BudgetPeriod `json:"period" enums:"week,month,year"`
+	Limit        types.Money        `json:"limit" swaggertype:"string" example:"500.00"`
+	Currency     types.Currency     `json:"currency" example:"USD"`
+	Rollover     bool               `json:"rollover"`
+}
+
+func (b Budget) Cursor() pagination.Cursor {
+	return pagination.Cursor{UUID: b.UUID}
+}
+
+func NewBudget(dto dto.CreateBudgetDTO) *Budget {
+	return &Budget{
+		CategoryUUID: dto.CategoryUUID,
+		Period:       dto.Period,
+		Limit:        dto.Limit,
+		Currency:     dto.Currency,
+		Rollover:     dto.Rollover,
+	}
+}
+
+func UpdatedBudget(existing Budget, dto dto.UpdateBudgetDTO) *Budget {
+	updBudget := new(Budget)
+
+	updBudget.UUID = dto.UUID
+	updBudget.UserUUID = existing.UserUUID
+	updBudget.CategoryUUID = existing.CategoryUUID
+	updBudget.Currency = existing.Currency
+
+	if dto.Period != "" {
+		updBudget.Period = dto.Period
+	} else {
+		updBudget.Period = existing.Period
+	}
+
+	if !dto.Limit.IsZero() {
+		updBudget.Limit = dto.Limit
+	} else {
+		updBudget.Limit = existing.Limit
+	}
+
+	if dto.Rollover != nil {
+		updBudget.Rollover = *dto.Rollover
+	} else {
+		updBudget.Rollover = existing.Rollover
+	}
+
+	return updBudget
+}
+
+// BudgetStatus shows spending of a budget in the period containing the current date.
+type BudgetStatus struct {
+	BudgetUUID  string         `json:"budget_uuid"`
+	Currency    types.Currency `json:"currency" example:"USD"`
+	PeriodStart time.Time      `json:"period_start"`
+	PeriodEnd   time.Time      `json:"period_end"`
+	Limit       types.Money    `json:"limit" swaggertype:"string" example:"500.00"`
+	RolledOver  types.Money    `json:"rolled_over" swaggertype:"string" example:"20.00"`
+	Spent       types.Money    `json:"spent" swaggertype:"string" example:"320.50"`
+	Remaining   types.Money    `json:"remaining" swaggertype:"string" example:"199.50"`
+	Overspent   bool           `json:"overspent"`
+}
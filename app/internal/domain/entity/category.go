@@ -3,20 +3,29 @@ package entity
 import (
 	"operation-service/internal/controller/dto"
 	"operation-service/internal/domain/types"
+	"operation-service/pkg/pagination"
 )
 
 type Category struct {
-	UUID     string             `json:"uuid"`
-	UserUUID string             `json:"user_uuid"`
-	Name     string             `json:"name"`
-	Type     types.CategoryType `json:"type"`
+	UUID       string             `json:"uuid"`
+	UserUUID   string             `json:"user_uuid"`
+	Name       string             `json:"name"`
+	Type       types.CategoryType `json:"type"`
+	Currency   types.Currency     `json:"currency,omitempty" example:"USD"`
+	ParentUUID string             `json:"parent_uuid,omitempty"`
+}
+
+func (c Category) Cursor() pagination.Cursor {
+	return pagination.Cursor{UUID: c.UUID}
 }
 
 func NewCategory(dto dto.CreateCategoryDTO) *Category {
 	return &Category{
-		UserUUID: dto.UserUUID,
-		Name:     dto.Name,
-		Type:     dto.Type,
+		UserUUID:   dto.UserUUID,
+		Name:       dto.Name,
+		Type:       dto.Type,
+		Currency:   dto.Currency,
+		ParentUUID: dto.ParentUUID,
 	}
 }
 
@@ -31,7 +40,58 @@ func UpdatedCategory(existing Category, dto dto.UpdateCategoryDTO) *Category {
 		updCategory.Name = existing.Name
 	}
 
+	if dto.Currency != "" {
+		updCategory.Currency = dto.Currency
+	} else {
+		updCategory.Currency = existing.Currency
+	}
+
+	if dto.ParentUUID != nil {
+		updCategory.ParentUUID = *dto.ParentUUID
+	} else {
+		updCategory.ParentUUID = existing.ParentUUID
+	}
+
+	updCategory.UserUUID = existing.UserUUID
 	updCategory.Type = existing.Type
 
 	return updCategory
 }
+
+// CategoryNode is a category with its subcategories.
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
+}
+
+// NewCategoryTree arranges categories into trees keeping their order among siblings.
+// Categories which parent is not among categories become roots.
+func NewCategoryTree(categories []Category) []CategoryNode {
+	known := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		known[category.UUID] = true
+	}
+
+	children := make(map[string][]Category)
+	roots := make([]Category, 0)
+	for _, category := range categories {
+		if category.ParentUUID == "" || !known[category.ParentUUID] {
+			roots = append(roots, category)
+			continue
+		}
+		children[category.ParentUUID] = append(children[category.ParentUUID], category)
+	}
+
+	var build func(categories []Category) []CategoryNode
+	build = func(categories []Category) []CategoryNode {
+		nodes := make([]CategoryNode, 0, len(categories))
+		for _, category := range categories {
+			nodes = append(nodes, CategoryNode{
+				Category: category,
+				Children: build(children[category.UUID]),
+			})
+		}
+		return nodes
+	}
+	return build(roots)
+}
@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+type ExchangeRate struct {
+	Base  types.Currency `json:"base" example:"EUR"`
+	Quote types.Currency `json:"quote" example:"USD"`
+	Rate  types.Rate     `json:"rate" swaggertype:"string" example:"1.0845"`
+	Date  time.Time      `json:"date"`
+}
+
+func NewExchangeRate(dto dto.CreateExchangeRateDTO, date time.Time) *ExchangeRate {
+	return &ExchangeRate{
+		Base:  dto.Base,
+		Quote: dto.Quote,
+		Rate:  dto.Rate,
+		Date:  date,
+	}
+}
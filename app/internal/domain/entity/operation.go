@@ -2,23 +2,40 @@ package entity
 
 import (
 	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/pagination"
 	"time"
 )
 
 type Operation struct {
-	UUID         string    `json:"uuid"`
-	CategoryUUID string    `json:"category_uuid"`
-	MoneySum     float64   `json:"money_sum"`
-	Description  string    `json:"description"`
-	DateTime     time.Time `json:"date_time"`
+	UUID         string         `json:"uuid"`
+	UserUUID     string         `json:"user_uuid"`
+	CategoryUUID string         `json:"category_uuid"`
+	AccountUUID  string         `json:"account_uuid,omitempty"`
+	TransferUUID string         `json:"transfer_uuid,omitempty"`
+	MoneySum     types.Money    `json:"money_sum" swaggertype:"string" example:"-12.30"`
+	Currency     types.Currency `json:"currency" example:"USD"`
+	Description  string         `json:"description"`
+	DateTime     time.Time      `json:"date_time"`
+}
+
+func (o Operation) Cursor() pagination.Cursor {
+	return pagination.Cursor{DateTime: &o.DateTime, UUID: o.UUID}
 }
 
 func NewOperation(dto dto.CreateOperationDTO) *Operation {
+	dateTime := time.Now()
+	if dto.DateTime != nil {
+		dateTime = *dto.DateTime
+	}
+
 	return &Operation{
 		CategoryUUID: dto.CategoryUUID,
+		AccountUUID:  dto.AccountUUID,
 		MoneySum:     dto.MoneySum,
+		Currency:     dto.Currency,
 		Description:  dto.Description,
-		DateTime:     time.Now(),
+		DateTime:     dateTime,
 	}
 }
 
@@ -26,6 +43,7 @@ func UpdatedOperation(existing Operation, dto dto.UpdateOperationDTO) *Operation
 	updOperation := new(Operation)
 
 	updOperation.UUID = dto.UUID
+	updOperation.UserUUID = existing.UserUUID
 
 	if dto.CategoryUUID != "" {
 		updOperation.CategoryUUID = dto.CategoryUUID
@@ -33,19 +51,94 @@ func UpdatedOperation(existing Operation, dto dto.UpdateOperationDTO) *Operation
 		updOperation.CategoryUUID = existing.CategoryUUID
 	}
 
-	if dto.MoneySum != 0 {
+	if dto.AccountUUID != "" {
+		updOperation.AccountUUID = dto.AccountUUID
+	} else {
+		updOperation.AccountUUID = existing.AccountUUID
+	}
+
+	if !dto.MoneySum.IsZero() {
 		updOperation.MoneySum = dto.MoneySum
 	} else {
 		updOperation.MoneySum = existing.MoneySum
 	}
 
+	if dto.Currency != "" {
+		updOperation.Currency = dto.Currency
+	} else {
+		updOperation.Currency = existing.Currency
+	}
+
 	if dto.Description != "" {
 		updOperation.Description = dto.Description
 	} else {
 		updOperation.Description = existing.Description
 	}
 
-	updOperation.DateTime = existing.DateTime
+	if dto.DateTime != nil {
+		updOperation.DateTime = *dto.DateTime
+	} else {
+		updOperation.DateTime = existing.DateTime
+	}
 
 	return updOperation
 }
+
+type OperationFilter struct {
+	UserUUID      string
+	CategoryUUIDs []string
+	AccountUUID   string
+	DateFrom      *time.Time
+	DateTo        *time.Time
+	MinSum        *types.Money
+	MaxSum        *types.Money
+	Currency      types.Currency
+	Description   string
+	Limit         int
+	After         *pagination.Cursor
+}
+
+func NewOperationFilter(dto dto.FindOperationsDTO) *OperationFilter {
+	return &OperationFilter{
+		UserUUID:      dto.UserUUID,
+		CategoryUUIDs: dto.CategoryUUIDs,
+		AccountUUID:   dto.AccountUUID,
+		DateFrom:      dto.DateFrom,
+		DateTo:        dto.DateTo,
+		MinSum:        dto.MinSum,
+		MaxSum:        dto.MaxSum,
+		Currency:      dto.Currency,
+		Description:   dto.Description,
+		Limit:         dto.Page.Limit,
+		After:         dto.Page.After,
+	}
+}
+
+// Balance holds totals of operations in a single currency.
+type Balance struct {
+	Currency types.Currency `json:"currency" example:"USD"`
+	Income   types.Money    `json:"income" swaggertype:"string" example:"100.00"`
+	Expense  types.Money    `json:"expense" swaggertype:"string" example:"40.50"`
+	Net      types.Money    `json:"net" swaggertype:"string" example:"59.50"`
+}
+
+// DailyBalance is a balance of a single currency and day as returned by storage.
+type DailyBalance struct {
+	Day time.Time
+	Balance
+}
+
+type BalanceFilter struct {
+	UserUUID     string
+	CategoryUUID string
+	DateFrom     *time.Time
+	DateTo       *time.Time
+}
+
+func NewBalanceFilter(dto dto.GetBalanceDTO) *BalanceFilter {
+	return &BalanceFilter{
+		UserUUID: dto.UserUUID,
+		DateFrom: dto.DateFrom,
+		DateTo:   dto.DateTo,
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	OperationCreatedEvent  = "operation.created"
+	OperationUpdatedEvent  = "operation.updated"
+	OperationDeletedEvent  = "operation.deleted"
+	OperationRestoredEvent = "operation.restored"
+	CategoryCreatedEvent   = "category.created"
+	CategoryUpdatedEvent   = "category.updated"
+	CategoryDeletedEvent   = "category.deleted"
+	CategoryRestoredEvent  = "category.restored"
+)
+
+// OutboxEvent is a domain event saved together with the change of the aggregate it describes.
+// Payload is the state of the aggregate after the change, or before it for deleted ones.
+type OutboxEvent struct {
+	UUID          string          `json:"uuid"`
+	Event         string          `json:"event"`
+	AggregateUUID string          `json:"aggregate_uuid"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+func NewOutboxEvent(event, aggregateUUID string, aggregate any) (*OutboxEvent, error) {
+	payload, err := json.Marshal(aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutboxEvent{
+		Event:         event,
+		AggregateUUID: aggregateUUID,
+		Payload:       payload,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+// RecurringOperation is a template of an operation repeated on a schedule. Every Interval
+// periods of Frequency from StartDate an operation is created, until EndDate or Count
+// occurrences, whichever comes first. Zero Count means no limit. Failures counts consecutive
+// failed attempts to create operations, the schedule is paused after too many of them.
+type RecurringOperation struct {
+	UUID         string          `json:"uuid"`
+	UserUUID     string          `json:"user_uuid"`
+	CategoryUUID string          `json:"category_uuid"`
+	AccountUUID  string          `json:"account_uuid,omitempty"`
+	MoneySum     types.Money     `json:"money_sum" swaggertype:"string" example:"12.30"`
+	Currency     types.Currency  `json:"currency,omitempty" example:"USD"`
+	Description  string          `json:"description"`
+	Frequency    types.Frequency `json:"frequency" enums:"daily,weekly,monthly,yearly"`
+	Interval     int             `json:"interval" example:"1"`
+	StartDate    time.Time       `json:"start_date"`
+	EndDate      *time.Time      `json:"end_date,omitempty"`
+	Count        int             `json:"count" example:"12"`
+	Occurrences  int             `json:"occurrences"`
+	NextDate     *time.Time      `json:"next_date,omitempty"`
+	Paused       bool            `json:"paused"`
+	Failures     int             `json:"failures"`
+}
+
+func (r RecurringOperation) Cursor() pagination.Cursor {
+	return pagination.Cursor{UUID: r.UUID}
+}
+
+func NewRecurringOperation(dto dto.CreateRecurringOperationDTO) *RecurringOperation {
+	startDate := time.Now()
+	if dto.StartDate != nil {
+		startDate = *dto.StartDate
+	}
+
+	recurring := &RecurringOperation{
+		CategoryUUID: dto.CategoryUUID,
+		AccountUUID:  dto.AccountUUID,
+		MoneySum:     dto.MoneySum,
+		Currency:     dto.Currency,
+		Description:  dto.Description,
+		Frequency:    dto.Frequency,
+		Interval:     dto.Interval,
+		StartDate:    startDate,
+		EndDate:      dto.EndDate,
+		Count:        dto.Count,
+	}
+	recurring.NextDate = recurring.nextDate()
+
+	return recurring
+}
+
+// Occurrence returns the date of the n-th occurrence, counting from zero.
+func (r RecurringOperation) Occurrence(n int) time.Time {
+	return r.Frequency.Add(r.StartDate, n*r.Interval)
+}
+
+// Advance moves the schedule past its next occurrence. NextDate becomes nil once
+// the schedule is over.
+func (r *RecurringOperation) Advance() {
+	r.Occurrences++
+	r.NextDate = r.nextDate()
+}
+
+func (r RecurringOperation) nextDate() *time.Time {
+	if r.Count > 0 && r.Occurrences >= r.Count {
+		return nil
+	}
+
+	next := r.Occurrence(r.Occurrences)
+	if r.EndDate != nil && next.After(*r.EndDate) {
+		return nil
+	}
+	return &next
+}
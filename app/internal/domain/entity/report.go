@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"time"
+)
+
+type CategoryReport struct {
+	CategoryUUID string             `json:"category_uuid"`
+	CategoryName string             `json:"category_name"`
+	CategoryType types.CategoryType `json:"category_type"`
+	ParentUUID   string             `json:"parent_uuid,omitempty"`
+	Buckets      []BucketSum        `json:"buckets"`
+}
+
+type BucketSum struct {
+	Currency types.Currency `json:"currency" example:"USD"`
+	Start    time.Time      `json:"start"`
+	Income   types.Money    `json:"income" swaggertype:"string" example:"100.00"`
+	Expense  types.Money    `json:"expense" swaggertype:"string" example:"40.50"`
+}
+
+// CategoryReportRow is a single category, bucket and currency aggregate as returned by storage.
+// Day is the start of the bucket, unless the report is requested by day for currency conversion.
+type CategoryReportRow struct {
+	CategoryUUID string
+	CategoryName string
+	CategoryType types.CategoryType
+	ParentUUID   string
+	Day          time.Time
+	BucketSum
+}
+
+type ReportFilter struct {
+	UserUUID string
+	DateFrom *time.Time
+	DateTo   *time.Time
+	Bucket   types.ReportBucket
+	ByDay    bool
+}
+
+func NewReportFilter(dto dto.GetCategoryReportDTO) *ReportFilter {
+	return &ReportFilter{
+		UserUUID: dto.UserUUID,
+		DateFrom: dto.DateFrom,
+		DateTo:   dto.DateTo,
+		Bucket:   dto.Bucket,
+	}
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"time"
+)
+
+// Transfer moves money between two accounts of a user. It is stored together with
+// a debit operation on the source account and a credit operation on the target one.
+type Transfer struct {
+	UUID            string         `json:"uuid"`
+	UserUUID        string         `json:"user_uuid"`
+	FromAccountUUID string         `json:"from_account_uuid"`
+	ToAccountUUID   string         `json:"to_account_uuid"`
+	MoneySum        types.Money    `json:"money_sum" swaggertype:"string" example:"100.00"`
+	Currency        types.Currency `json:"currency" example:"USD"`
+	Description     string         `json:"description"`
+	DateTime        time.Time      `json:"date_time"`
+}
+
+func NewTransfer(dto dto.CreateTransferDTO) *Transfer {
+	dateTime := time.Now()
+	if dto.DateTime != nil {
+		dateTime = *dto.DateTime
+	}
+
+	return &Transfer{
+		FromAccountUUID: dto.FromAccountUUID,
+		ToAccountUUID:   dto.ToAccountUUID,
+		MoneySum:        dto.MoneySum,
+		Description:     dto.Description,
+		DateTime:        dateTime,
+	}
+}
+
+func UpdatedTransfer(existing Transfer, dto dto.UpdateTransferDTO) *Transfer {
+	updTransfer := new(Transfer)
+
+	updTransfer.UUID = dto.UUID
+	updTransfer.UserUUID = existing.UserUUID
+	updTransfer.Currency = existing.Currency
+
+	if dto.FromAccountUUID != "" {
+		updTransfer.FromAccountUUID = dto.FromAccountUUID
+	} else {
+		updTransfer.FromAccountUUID = existing.FromAccountUUID
+	}
+
+	if dto.ToAccountUUID != "" {
+		updTransfer.ToAccountUUID = dto.ToAccountUUID
+	} else {
+		updTransfer.ToAccountUUID = existing.ToAccountUUID
+	}
+
+	if !dto.MoneySum.IsZero() {
+		updTransfer.MoneySum = dto.MoneySum
+	} else {
+		updTransfer.MoneySum = existing.MoneySum
+	}
+
+	if dto.Description != "" {
+		updTransfer.Description = dto.Description
+	} else {
+		updTransfer.Description = existing.Description
+	}
+
+	if dto.DateTime != nil {
+		updTransfer.DateTime = *dto.DateTime
+	} else {
+		updTransfer.DateTime = existing.DateTime
+	}
+
+	return updTransfer
+}
+
+// Debit returns the operation withdrawing the transfer sum from the source account.
+func (t Transfer) Debit() Operation {
+	return Operation{
+		UserUUID:     t.UserUUID,
+		AccountUUID:  t.FromAccountUUID,
+		TransferUUID: t.UUID,
+		MoneySum:     t.MoneySum.Neg(),
+		Currency:     t.Currency,
+		Description:  t.Description,
+		DateTime:     t.DateTime,
+	}
+}
+
+// Credit returns the operation depositing the transfer sum to the target account.
+func (t Transfer) Credit() Operation {
+	return Operation{
+		UserUUID:     t.UserUUID,
+		AccountUUID:  t.ToAccountUUID,
+		TransferUUID: t.UUID,
+		MoneySum:     t.MoneySum,
+		Currency:     t.Currency,
+		Description:  t.Description,
+		DateTime:     t.DateTime,
+	}
+}
@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"encoding/json"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+const BudgetThresholdEvent = "budget.threshold_crossed"
+
+// Webhook is a user's subscription to events. Payloads are signed with Secret.
+type Webhook struct {
+	UUID      string    `json:"uuid"`
+	UserUUID  string    `json:"user_uuid"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (w Webhook) Cursor() pagination.Cursor {
+	return pagination.Cursor{UUID: w.UUID}
+}
+
+func NewWebhook(dto dto.CreateWebhookDTO) *Webhook {
+	return &Webhook{
+		UserUUID: dto.UserUUID,
+		URL:      dto.URL,
+		Secret:   dto.Secret,
+	}
+}
+
+// WebhookDelivery is an event sent or to be sent to a webhook.
+type WebhookDelivery struct {
+	UUID           string               `json:"uuid"`
+	WebhookUUID    string               `json:"webhook_uuid"`
+	Event          string               `json:"event"`
+	Payload        json.RawMessage      `json:"payload" swaggertype:"object"`
+	Status         types.DeliveryStatus `json:"status" enums:"pending,delivered,failed"`
+	Attempts       int                  `json:"attempts"`
+	NextAttemptAt  *time.Time           `json:"next_attempt_at,omitempty"`
+	ResponseStatus *int                 `json:"response_status,omitempty"`
+	LastError      string               `json:"last_error,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	DeliveredAt    *time.Time           `json:"delivered_at,omitempty"`
+	// URL and Secret of the webhook, they are loaded only to send the delivery
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+func (d WebhookDelivery) Cursor() pagination.Cursor {
+	return pagination.Cursor{DateTime: &d.CreatedAt, UUID: d.UUID}
+}
+
+// WebhookEvent is the payload of a delivery.
+type WebhookEvent struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// BudgetAlert is data of BudgetThresholdEvent, it is sent when spending of a budget
+// reaches Threshold percent of the limit (with rolled over sum) in the current period.
+type BudgetAlert struct {
+	CategoryUUID string `json:"category_uuid"`
+	Threshold    int    `json:"threshold" example:"80"`
+	BudgetStatus
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+)
+
+type AccountRepo interface {
+	Create(ctx context.Context, account entity.Account) (string, error)
+	FindByUUID(ctx context.Context, uuid string) (entity.Account, error)
+	FindByUserUUID(ctx context.Context, uuid string, page pagination.Params) ([]entity.Account, error)
+	Balance(ctx context.Context, uuid string) (entity.AccountBalance, error)
+	HasOperations(ctx context.Context, uuid string) (bool, error)
+	HasRecurringOperations(ctx context.Context, uuid string) (bool, error)
+	Update(ctx context.Context, account entity.Account) error
+	Delete(ctx context.Context, uuid string) error
+}
+
+type accountService struct {
+	repository AccountRepo
+	logger     *logging.Logger
+}
+
+func NewAccountService(repository AccountRepo, logger *logging.Logger) controller.AccountService {
+	return &accountService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *accountService) Create(ctx context.Context, dto dto.CreateAccountDTO) (string, error) {
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if dto.UserUUID == "" {
+		dto.UserUUID = userUUID
+	}
+	if dto.UserUUID != userUUID {
+		return "", apperror.ErrNotFound
+	}
+
+	account := entity.NewAccount(dto)
+	accountUUID, err := s.repository.Create(ctx, *account)
+	if err != nil {
+		return accountUUID, fmt.Errorf("failed to create account: %w", err)
+	}
+	return accountUUID, nil
+}
+
+func (s *accountService) GetByUUID(ctx context.Context, uuid string) (entity.Account, error) {
+	account, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return account, fmt.Errorf("failed to get account by uuid: %w", err)
+	}
+	if err = checkOwner(ctx, account.UserUUID); err != nil {
+		return entity.Account{}, err
+	}
+	return account, nil
+}
+
+func (s *accountService) GetByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.Account], error) {
+	if err := checkOwner(ctx, uuid); err != nil {
+		return pagination.Page[entity.Account]{}, err
+	}
+
+	accounts, err := s.repository.FindByUserUUID(ctx, uuid, page)
+	if err != nil {
+		return pagination.Page[entity.Account]{}, fmt.Errorf("failed to get accounts by user uuid: %w", err)
+	}
+	return pagination.NewPage(accounts, page.Limit, entity.Account.Cursor), nil
+}
+
+func (s *accountService) GetBalance(ctx context.Context, uuid string) (entity.AccountBalance, error) {
+	account, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return entity.AccountBalance{}, err
+	}
+	if err = checkOwner(ctx, account.UserUUID); err != nil {
+		return entity.AccountBalance{}, err
+	}
+
+	balance, err := s.repository.Balance(ctx, uuid)
+	if err != nil {
+		return balance, fmt.Errorf("failed to get account balance: %w", err)
+	}
+	return balance, nil
+}
+
+func (s *accountService) Update(ctx context.Context, dto dto.UpdateAccountDTO) error {
+	account, err := s.repository.FindByUUID(ctx, dto.UUID)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, account.UserUUID); err != nil {
+		return err
+	}
+
+	updAccount := entity.UpdatedAccount(account, dto)
+
+	err = s.repository.Update(ctx, *updAccount)
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+	return nil
+}
+
+func (s *accountService) Delete(ctx context.Context, uuid string) error {
+	account, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, account.UserUUID); err != nil {
+		return err
+	}
+
+	hasOperations, err := s.repository.HasOperations(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to check account operations: %w", err)
+	}
+	if hasOperations {
+		return apperror.ValidationError("account with operations can not be deleted")
+	}
+
+	hasRecurringOperations, err := s.repository.HasRecurringOperations(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to check account recurring operations: %w", err)
+	}
+	if hasRecurringOperations {
+		return apperror.ValidationError("account used by recurring operations can not be deleted")
+	}
+
+	err = s.repository.Delete(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"testing"
+)
+
+type fakeAccountRepo struct {
+	AccountRepo
+	accounts               map[string]entity.Account
+	hasOperations          bool
+	hasRecurringOperations bool
+	deleted                []string
+}
+
+func newFakeAccountRepo(accounts ...entity.Account) *fakeAccountRepo {
+	r := &fakeAccountRepo{accounts: make(map[string]entity.Account)}
+	for _, account := range accounts {
+		r.accounts[account.UUID] = account
+	}
+	return r
+}
+
+func (r *fakeAccountRepo) FindByUUID(_ context.Context, uuid string) (entity.Account, error) {
+	account, ok := r.accounts[uuid]
+	if !ok {
+		return entity.Account{}, apperror.ErrNotFound
+	}
+	return account, nil
+}
+
+func (r *fakeAccountRepo) HasOperations(context.Context, string) (bool, error) {
+	return r.hasOperations, nil
+}
+
+func (r *fakeAccountRepo) HasRecurringOperations(context.Context, string) (bool, error) {
+	return r.hasRecurringOperations, nil
+}
+
+func (r *fakeAccountRepo) Delete(_ context.Context, uuid string) error {
+	r.deleted = append(r.deleted, uuid)
+	return nil
+}
+
+func TestAccountServiceDelete(t *testing.T) {
+	account := entity.Account{UUID: "a1", UserUUID: "u1", Currency: "USD"}
+
+	tests := []struct {
+		name                   string
+		user                   string
+		hasOperations          bool
+		hasRecurringOperations bool
+		wantStatus             int
+	}{
+		{name: "unused account", user: "u1"},
+		{name: "account of another user", user: "u2", wantStatus: http.StatusNotFound},
+		{name: "account with operations", user: "u1", hasOperations: true, wantStatus: http.StatusBadRequest},
+		{name: "account used by recurring operations", user: "u1", hasRecurringOperations: true,
+			wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeAccountRepo(account)
+			repo.hasOperations, repo.hasRecurringOperations = tt.hasOperations, tt.hasRecurringOperations
+			s := NewAccountService(repo, newTestLogger())
+
+			err := s.Delete(userContext(tt.user), "a1")
+			if status := errorStatus(err); status != tt.wantStatus {
+				t.Fatalf("Delete() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if deleted := len(repo.deleted) > 0; deleted != (tt.wantStatus == 0) {
+				t.Errorf("account deleted = %t, want %t", deleted, tt.wantStatus == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/requestctx"
+)
+
+type AuditRepo interface {
+	Save(ctx context.Context, record entity.AuditRecord) error
+	FindByEntity(ctx context.Context, entityType, uuid string, page pagination.Params) ([]entity.AuditRecord, error)
+}
+
+// saveAudit records the change of the entity made by the user of the request carried by ctx.
+// It must be called within the transaction which changes the entity.
+func saveAudit(ctx context.Context, auditRepo AuditRepo, entityType, entityUUID, action string,
+	before, after any) error {
+	record, err := entity.NewAuditRecord(entityType, entityUUID, action, before, after)
+	if err != nil {
+		return fmt.Errorf("failed to diff %s %s: %w", entityType, entityUUID, err)
+	}
+	record.Actor, _ = requestctx.UserUUID(ctx)
+	record.RequestID = requestctx.RequestID(ctx)
+
+	return auditRepo.Save(ctx, *record)
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+type BudgetRepo interface {
+	Create(ctx context.Context, budget entity.Budget) (string, error)
+	FindByUUID(ctx context.Context, uuid string) (entity.Budget, error)
+	FindByUserUUID(ctx context.Context, uuid string, page pagination.Params) ([]entity.Budget, error)
+	FindByCategoryUUID(ctx context.Context, uuid string) ([]entity.Budget, error)
+	SaveAlert(ctx context.Context, uuid string, periodStart time.Time, threshold int) (bool, error)
+	Update(ctx context.Context, budget entity.Budget) error
+	Delete(ctx context.Context, uuid string) error
+}
+
+type budgetService struct {
+	repository    BudgetRepo
+	categoryRepo  CategoryRepo
+	operationRepo OperationRepo
+	rateProvider  ExchangeRateProvider
+	logger        *logging.Logger
+}
+
+func NewBudgetService(repository BudgetRepo, categoryRepo CategoryRepo, operationRepo OperationRepo,
+	rateProvider ExchangeRateProvider, logger *logging.Logger) controller.BudgetService {
+	return &budgetService{
+		repository:    repository,
+		categoryRepo:  categoryRepo,
+		operationRepo: operationRepo,
+		rateProvider:  rateProvider,
+		logger:        logger,
+	}
+}
+
+func (s *budgetService) Create(ctx context.Context, dto dto.CreateBudgetDTO) (string, error) {
+	if dto.Period == "" {
+		dto.Period = types.MonthPeriod
+	}
+
+	category, err := s.categoryRepo.FindByUUID(ctx, dto.CategoryUUID)
+	if err != nil {
+		return "", err
+	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return "", err
+	}
+	if category.Type != types.ExpenseType {
+		return "", apperror.ValidationError("budget can be set only for expense category")
+	}
+
+	budget := entity.NewBudget(dto)
+	if budget.Currency == "" {
+		budget.Currency = category.Currency
+	}
+	if budget.Currency == "" {
+		return "", apperror.ValidationError("currency must be specified as category has no default currency")
+	}
+
+	budgetUUID, err := s.repository.Create(ctx, *budget)
+	if err != nil {
+		return "", fmt.Errorf("failed to create budget: %w", err)
+	}
+	return budgetUUID, nil
+}
+
+func (s *budgetService) GetByUUID(ctx context.Context, uuid string) (entity.Budget, error) {
+	budget, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return budget, fmt.Errorf("failed to find budget by uuid: %w", err)
+	}
+	if err = checkOwner(ctx, budget.UserUUID); err != nil {
+		return entity.Budget{}, err
+	}
+	return budget, nil
+}
+
+func (s *budgetService) GetByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.Budget], error) {
+	if err := checkOwner(ctx, uuid); err != nil {
+		return pagination.Page[entity.Budget]{}, err
+	}
+
+	budgets, err := s.repository.FindByUserUUID(ctx, uuid, page)
+	if err != nil {
+		return pagination.Page[entity.Budget]{}, fmt.Errorf("failed to find budgets by user uuid: %w", err)
+	}
+	return pagination.NewPage(budgets, page.Limit, entity.Budget.Cursor), nil
+}
+
+func (s *budgetService) GetStatus(ctx context.Context, uuid string) (entity.BudgetStatus, error) {
+	budget, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return entity.BudgetStatus{}, err
+	}
+	if err = checkOwner(ctx, budget.UserUUID); err != nil {
+		return entity.BudgetStatus{}, err
+	}
+
+	return budgetStatus(ctx, s.operationRepo, s.rateProvider, budget, time.Now())
+}
+
+// budgetStatus calculates spending of the budget in the period containing now.
+func budgetStatus(ctx context.Context, operationRepo OperationRepo, rateProvider ExchangeRateProvider,
+	budget entity.Budget, now time.Time) (entity.BudgetStatus, error) {
+	start := budget.Period.Start(now)
+	end := budget.Period.Next(start)
+
+	conv := newConverter(rateProvider, budget.Currency)
+	spent, err := budgetSpent(ctx, operationRepo, conv, budget, start, end)
+	if err != nil {
+		return entity.BudgetStatus{}, err
+	}
+
+	status := entity.BudgetStatus{
+		BudgetUUID:  budget.UUID,
+		Currency:    budget.Currency,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Limit:       budget.Limit,
+		Spent:       spent,
+	}
+
+	if budget.Rollover {
+		prevSpent, err := budgetSpent(ctx, operationRepo, conv, budget, budget.Period.Previous(start), start)
+		if err != nil {
+			return entity.BudgetStatus{}, err
+		}
+		status.RolledOver = budget.Limit.Sub(prevSpent)
+	}
+
+	status.Remaining = status.Limit.Add(status.RolledOver).Sub(status.Spent)
+	status.Overspent = status.Remaining.IsNegative()
+
+	return status, nil
+}
+
+// budgetSpent sums expenses of the budget's category from from up to, but not including, to.
+// Expenses in other currencies are converted to the budget's one at the rate of their day.
+func budgetSpent(ctx context.Context, operationRepo OperationRepo, conv *converter, budget entity.Budget,
+	from, to time.Time) (types.Money, error) {
+	// date to of the balance filter is inclusive
+	dateTo := to.Add(-time.Microsecond)
+	filter := entity.BalanceFilter{
+		UserUUID:     budget.UserUUID,
+		CategoryUUID: budget.CategoryUUID,
+		DateFrom:     &from,
+		DateTo:       &dateTo,
+	}
+
+	dailyBalances, err := operationRepo.DailyBalance(ctx, filter)
+	if err != nil {
+		return types.Money{}, fmt.Errorf("failed to calculate daily balance: %w", err)
+	}
+
+	var spent types.Money
+	for _, daily := range dailyBalances {
+		expense := daily.Expense
+		if daily.Currency != budget.Currency {
+			expense, err = conv.convert(ctx, daily.Expense, daily.Currency, daily.Day)
+			if err != nil {
+				return types.Money{}, err
+			}
+		}
+		spent = spent.Add(expense)
+	}
+	return spent, nil
+}
+
+func (s *budgetService) Update(ctx context.Context, dto dto.UpdateBudgetDTO) error {
+	budget, err := s.repository.FindByUUID(ctx, dto.UUID)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, budget.UserUUID); err != nil {
+		return err
+	}
+
+	updBudget := entity.UpdatedBudget(budget, dto)
+
+	err = s.repository.Update(ctx, *updBudget)
+	if err != nil {
+		return fmt.Errorf("failed to update budget: %w", err)
+	}
+	return nil
+}
+
+func (s *budgetService) Delete(ctx context.Context, uuid string) error {
+	budget, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, budget.UserUUID); err != nil {
+		return err
+	}
+
+	err = s.repository.Delete(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to delete budget by uuid: %w", err)
+	}
+	return nil
+}
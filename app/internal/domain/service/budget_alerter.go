@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"time"
+)
+
+// budgetThresholds are percents of budget limit which are alerted when reached, in ascending order.
+var budgetThresholds = []int{80, 100}
+
+// BudgetAlerter sends webhook events when spending of a budget reaches a threshold.
+type BudgetAlerter struct {
+	budgetRepo    BudgetRepo
+	webhookRepo   WebhookRepo
+	operationRepo OperationRepo
+	rateProvider  ExchangeRateProvider
+	transactor    Transactor
+	logger        *logging.Logger
+}
+
+func NewBudgetAlerter(budgetRepo BudgetRepo, webhookRepo WebhookRepo, operationRepo OperationRepo,
+	rateProvider ExchangeRateProvider, transactor Transactor, logger *logging.Logger) *BudgetAlerter {
+	return &BudgetAlerter{
+		budgetRepo:    budgetRepo,
+		webhookRepo:   webhookRepo,
+		operationRepo: operationRepo,
+		rateProvider:  rateProvider,
+		transactor:    transactor,
+		logger:        logger,
+	}
+}
+
+// Check queues BudgetThresholdEvent for budgets of the category which spending in the current
+// period reached a threshold. Every threshold is alerted once per period. Errors are logged only,
+// as the change of operations which triggered the check is already made.
+func (a *BudgetAlerter) Check(ctx context.Context, categoryUUID string) {
+	if categoryUUID == "" {
+		return
+	}
+
+	budgets, err := a.budgetRepo.FindByCategoryUUID(ctx, categoryUUID)
+	if err != nil {
+		a.logger.Errorf("failed to find budgets of category %s: %v", categoryUUID, err)
+		return
+	}
+
+	now := time.Now()
+	for _, budget := range budgets {
+		if err = a.check(ctx, budget, now); err != nil {
+			a.logger.Errorf("failed to check thresholds of budget %s: %v", budget.UUID, err)
+		}
+	}
+}
+
+func (a *BudgetAlerter) check(ctx context.Context, budget entity.Budget, now time.Time) error {
+	status, err := budgetStatus(ctx, a.operationRepo, a.rateProvider, budget, now)
+	if err != nil {
+		return err
+	}
+
+	for _, threshold := range reachedThresholds(status.Spent, status.Limit.Add(status.RolledOver)) {
+		err = a.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			saved, err := a.budgetRepo.SaveAlert(ctx, budget.UUID, status.PeriodStart, threshold)
+			if err != nil || !saved {
+				return err
+			}
+
+			payload, err := json.Marshal(entity.WebhookEvent{
+				Event:     entity.BudgetThresholdEvent,
+				CreatedAt: now,
+				Data: entity.BudgetAlert{
+					CategoryUUID: budget.CategoryUUID,
+					Threshold:    threshold,
+					BudgetStatus: status,
+				},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to marshal budget alert: %w", err)
+			}
+
+			return a.webhookRepo.CreateDeliveries(ctx, budget.UserUUID, entity.BudgetThresholdEvent, payload)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reachedThresholds returns thresholds of budgetThresholds reached by spending of the limit. A limit
+// used up by overspending of the previous period is exceeded by any spending, which is alerted with
+// the highest threshold only.
+func reachedThresholds(spent, limit types.Money) []int {
+	if limit.Minor() <= 0 {
+		if spent.Minor() <= 0 {
+			return nil
+		}
+		return budgetThresholds[len(budgetThresholds)-1:]
+	}
+
+	var reached []int
+	for _, threshold := range budgetThresholds {
+		// spent / limit < threshold / 100
+		if spent.Minor()*100 < limit.Minor()*int64(threshold) {
+			break
+		}
+		reached = append(reached, threshold)
+	}
+	return reached
+}
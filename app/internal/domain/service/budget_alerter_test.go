@@ -0,0 +1,35 @@
+package service
+
+import (
+	"fmt"
+	"operation-service/internal/domain/types"
+	"testing"
+)
+
+func TestReachedThresholds(t *testing.T) {
+	tests := []struct {
+		name  string
+		spent int64
+		limit int64
+		want  []int
+	}{
+		{name: "nothing spent", spent: 0, limit: 10000, want: nil},
+		{name: "below first threshold", spent: 7999, limit: 10000, want: nil},
+		{name: "first threshold", spent: 8000, limit: 10000, want: []int{80}},
+		{name: "limit reached", spent: 10000, limit: 10000, want: []int{80, 100}},
+		{name: "limit exceeded", spent: 25000, limit: 10000, want: []int{80, 100}},
+		{name: "zero limit without spending", spent: 0, limit: 0, want: nil},
+		{name: "zero limit", spent: 1, limit: 0, want: []int{100}},
+		{name: "negative limit without spending", spent: 0, limit: -5000, want: nil},
+		{name: "negative limit", spent: 100, limit: -5000, want: []int{100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := reachedThresholds(types.MoneyFromMinor(tt.spent), types.MoneyFromMinor(tt.limit))
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("reachedThresholds(%d, %d) = %v, want %v", tt.spent, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
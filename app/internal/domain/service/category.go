@@ -2,46 +2,87 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"operation-service/internal/apperror"
 	"operation-service/internal/controller/dto"
 	"operation-service/internal/controller/http"
 	"operation-service/internal/domain/entity"
-	"operation-service/internal/domain/types"
 	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"time"
 )
 
 type CategoryRepo interface {
 	Create(ctx context.Context, category entity.Category) (string, error)
 	FindByUUID(ctx context.Context, uuid string) (entity.Category, error)
-	FindByUserUUID(ctx context.Context, uuid string) ([]entity.Category, error)
+	FindByUserUUID(ctx context.Context, uuid string, page pagination.Params) ([]entity.Category, error)
+	FindAllByUserUUID(ctx context.Context, uuid string) ([]entity.Category, error)
+	HasChildren(ctx context.Context, uuid string) (bool, error)
+	HasOperations(ctx context.Context, uuid string) (bool, error)
+	ReassignOperations(ctx context.Context, fromUUID, toUUID string) ([]entity.Operation, error)
+	DeleteOperations(ctx context.Context, uuid string) ([]entity.Operation, error)
 	Update(ctx context.Context, category entity.Category) error
 	Delete(ctx context.Context, uuid string) error
+	FindDeletedByUUID(ctx context.Context, uuid string) (entity.Category, error)
+	Restore(ctx context.Context, uuid string) ([]entity.Operation, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 type categoryService struct {
 	repository CategoryRepo
+	outboxRepo OutboxRepo
+	auditRepo  AuditRepo
+	transactor Transactor
 	logger     *logging.Logger
 }
 
-func NewCategoryService(repository CategoryRepo, logger *logging.Logger) controller.CategoryService {
+func NewCategoryService(repository CategoryRepo, outboxRepo OutboxRepo, auditRepo AuditRepo, transactor Transactor,
+	logger *logging.Logger) controller.CategoryService {
 	return &categoryService{
 		repository: repository,
+		outboxRepo: outboxRepo,
+		auditRepo:  auditRepo,
+		transactor: transactor,
 		logger:     logger,
 	}
 }
 
 func (s *categoryService) Create(ctx context.Context, dto dto.CreateCategoryDTO) (string, error) {
-	if dto.Type != types.IncomeType && dto.Type != types.ExpenseType {
-		return "", apperror.BadRequestError("category type must be 'Income' or 'Expense'")
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if dto.UserUUID == "" {
+		dto.UserUUID = userUUID
+	}
+	if dto.UserUUID != userUUID {
+		return "", apperror.ErrNotFound
 	}
 
 	category := entity.NewCategory(dto)
-	categoryUUID, err := s.repository.Create(ctx, *category)
+	if err = s.checkParent(ctx, *category); err != nil {
+		return "", err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		categoryUUID, err := s.repository.Create(ctx, *category)
+		if err != nil {
+			return err
+		}
+		category.UUID = categoryUUID
+
+		err = saveAudit(ctx, s.auditRepo, entity.CategoryAuditEntity, category.UUID, entity.CreateAuditAction,
+			nil, category)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.CategoryCreatedEvent, category.UUID, category)
+	})
 	if err != nil {
-		return categoryUUID, fmt.Errorf("failed to create category: %w", err)
+		return "", fmt.Errorf("failed to create category: %w", err)
 	}
-	return categoryUUID, nil
+	return category.UUID, nil
 }
 
 func (s *categoryService) GetByUUID(ctx context.Context, uuid string) (entity.Category, error) {
@@ -49,15 +90,35 @@ func (s *categoryService) GetByUUID(ctx context.Context, uuid string) (entity.Ca
 	if err != nil {
 		return category, fmt.Errorf("failed to get category by uuid: %w", err)
 	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return entity.Category{}, err
+	}
 	return category, nil
 }
 
-func (s *categoryService) GetByUserUUID(ctx context.Context, uuid string) ([]entity.Category, error) {
-	categories, err := s.repository.FindByUserUUID(ctx, uuid)
+func (s *categoryService) GetByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.Category], error) {
+	if err := checkOwner(ctx, uuid); err != nil {
+		return pagination.Page[entity.Category]{}, err
+	}
+
+	categories, err := s.repository.FindByUserUUID(ctx, uuid, page)
 	if err != nil {
-		return categories, fmt.Errorf("failed to get categories by user uuid: %w", err)
+		return pagination.Page[entity.Category]{}, fmt.Errorf("failed to get categories by user uuid: %w", err)
 	}
-	return categories, nil
+	return pagination.NewPage(categories, page.Limit, entity.Category.Cursor), nil
+}
+
+func (s *categoryService) GetTreeByUserUUID(ctx context.Context, uuid string) ([]entity.CategoryNode, error) {
+	if err := checkOwner(ctx, uuid); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.repository.FindAllByUserUUID(ctx, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories by user uuid: %w", err)
+	}
+	return entity.NewCategoryTree(categories), nil
 }
 
 func (s *categoryService) Update(ctx context.Context, dto dto.UpdateCategoryDTO) error {
@@ -65,25 +126,234 @@ func (s *categoryService) Update(ctx context.Context, dto dto.UpdateCategoryDTO)
 	if err != nil {
 		return err
 	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return err
+	}
 
 	updCategory := entity.UpdatedCategory(category, dto)
+	if updCategory.ParentUUID != category.ParentUUID {
+		if err = s.checkParent(ctx, *updCategory); err != nil {
+			return err
+		}
+	}
 
-	err = s.repository.Update(ctx, *updCategory)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Update(ctx, *updCategory); err != nil {
+			return err
+		}
+		err := saveAudit(ctx, s.auditRepo, entity.CategoryAuditEntity, updCategory.UUID, entity.UpdateAuditAction,
+			category, updCategory)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.CategoryUpdatedEvent, updCategory.UUID, updCategory)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update category: %w", err)
 	}
 	return nil
 }
 
-func (s *categoryService) Delete(ctx context.Context, uuid string) error {
-	_, err := s.repository.FindByUUID(ctx, uuid)
+func (s *categoryService) Delete(ctx context.Context, dto dto.DeleteCategoryDTO) error {
+	category, err := s.repository.FindByUUID(ctx, dto.UUID)
 	if err != nil {
 		return err
 	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return err
+	}
 
-	err = s.repository.Delete(ctx, uuid)
+	hasChildren, err := s.repository.HasChildren(ctx, dto.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to check subcategories: %w", err)
+	}
+	if hasChildren {
+		return apperror.ValidationError("category with subcategories can not be deleted")
+	}
+
+	if dto.ReassignTo != "" {
+		if err = s.checkReassignTarget(ctx, category, dto.ReassignTo); err != nil {
+			return err
+		}
+	} else if !dto.Cascade {
+		hasOperations, err := s.repository.HasOperations(ctx, dto.UUID)
+		if err != nil {
+			return fmt.Errorf("failed to check category operations: %w", err)
+		}
+		if hasOperations {
+			return apperror.ValidationError("category with operations can be deleted only with reassign to " +
+				"another category or cascade")
+		}
+	}
+
+	// operations moved or deleted with the category have no events of their own,
+	// the event tells where they are moved to. They are audited one by one, as the audit
+	// trail of an operation must be complete.
+	deletion := struct {
+		entity.Category
+		ReassignedTo string `json:"reassigned_to,omitempty"`
+	}{Category: category, ReassignedTo: dto.ReassignTo}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		switch {
+		case dto.ReassignTo != "":
+			operations, err := s.repository.ReassignOperations(ctx, dto.UUID, dto.ReassignTo)
+			if err != nil {
+				return err
+			}
+			for _, operation := range operations {
+				operation.UserUUID = category.UserUUID
+				before := operation
+				before.CategoryUUID = category.UUID
+				err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID,
+					entity.UpdateAuditAction, before, operation)
+				if err != nil {
+					return err
+				}
+			}
+		case dto.Cascade:
+			operations, err := s.repository.DeleteOperations(ctx, dto.UUID)
+			if err != nil {
+				return err
+			}
+			for _, operation := range operations {
+				operation.UserUUID = category.UserUUID
+				err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID,
+					entity.DeleteAuditAction, operation, nil)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.repository.Delete(ctx, dto.UUID); err != nil {
+			return err
+		}
+		err := saveAudit(ctx, s.auditRepo, entity.CategoryAuditEntity, category.UUID, entity.DeleteAuditAction,
+			category, nil)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.CategoryDeletedEvent, category.UUID, deletion)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete category: %w", err)
 	}
-	return err
+	return nil
+}
+
+// Restore restores deleted category together with operations deleted with it. The parent
+// of the category must not be deleted. As on deletion, the restored operations have no events
+// of their own but are audited one by one.
+func (s *categoryService) Restore(ctx context.Context, uuid string) error {
+	category, err := s.repository.FindDeletedByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return err
+	}
+
+	if category.ParentUUID != "" {
+		_, err = s.repository.FindByUUID(ctx, category.ParentUUID)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return apperror.ValidationError("parent category is deleted and must be restored first")
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		operations, err := s.repository.Restore(ctx, uuid)
+		if err != nil {
+			return err
+		}
+		for _, operation := range operations {
+			operation.UserUUID = category.UserUUID
+			err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID,
+				entity.RestoreAuditAction, nil, operation)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = saveAudit(ctx, s.auditRepo, entity.CategoryAuditEntity, category.UUID, entity.RestoreAuditAction,
+			nil, category)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.CategoryRestoredEvent, category.UUID, category)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore category: %w", err)
+	}
+	return nil
+}
+
+// checkReassignTarget checks that operations of the category can be moved to the target category.
+func (s *categoryService) checkReassignTarget(ctx context.Context, category entity.Category, uuid string) error {
+	if uuid == category.UUID {
+		return apperror.FieldValidationError("reassign_to", apperror.CodeInvalid,
+			"operations can not be reassigned to the deleted category")
+	}
+
+	target, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+		return err
+	}
+	// category of another user is not disclosed
+	if err != nil || target.UserUUID != category.UserUUID {
+		return apperror.FieldValidationError("reassign_to", apperror.CodeNotFound,
+			"category to reassign operations to is not found")
+	}
+	if target.Type != category.Type {
+		return apperror.FieldValidationError("reassign_to", apperror.CodeMismatch,
+			"category to reassign operations to must have the same type")
+	}
+	return nil
+}
+
+// checkParent checks that the parent of the category belongs to the same user, has the same type
+// and is neither the category itself nor one of its subcategories.
+func (s *categoryService) checkParent(ctx context.Context, category entity.Category) error {
+	if category.ParentUUID == "" {
+		return nil
+	}
+
+	parent, err := s.repository.FindByUUID(ctx, category.ParentUUID)
+	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+		return err
+	}
+	// category of another user is not disclosed
+	if err != nil || parent.UserUUID != category.UserUUID {
+		return apperror.FieldValidationError("parent_uuid", apperror.CodeNotFound, "parent category is not found")
+	}
+	if parent.Type != category.Type {
+		return apperror.FieldValidationError("parent_uuid", apperror.CodeMismatch,
+			"category type must match parent category type")
+	}
+
+	if category.UUID == "" {
+		return nil
+	}
+
+	// the category must not be found among ancestors of its new parent
+	visited := make(map[string]bool)
+	for ancestor := parent; !visited[ancestor.UUID]; {
+		if ancestor.UUID == category.UUID {
+			return apperror.FieldValidationError("parent_uuid", apperror.CodeInvalid,
+				"category can not be nested into itself or its subcategory")
+		}
+		if ancestor.ParentUUID == "" {
+			return nil
+		}
+		visited[ancestor.UUID] = true
+
+		ancestor, err = s.repository.FindByUUID(ctx, ancestor.ParentUUID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
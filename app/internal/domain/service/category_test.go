@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"testing"
+)
+
+// fakeCategoryRepo keeps categories in memory. Operations of every category are the same
+// operations, which are returned by the methods moving, deleting or restoring them.
+type fakeCategoryRepo struct {
+	CategoryRepo
+	categories map[string]entity.Category
+	deleted    map[string]bool
+	operations []entity.Operation
+}
+
+func newFakeCategoryRepo(operations []entity.Operation, categories ...entity.Category) *fakeCategoryRepo {
+	r := &fakeCategoryRepo{
+		categories: make(map[string]entity.Category),
+		deleted:    make(map[string]bool),
+		operations: operations,
+	}
+	for _, category := range categories {
+		r.categories[category.UUID] = category
+	}
+	return r
+}
+
+func (r *fakeCategoryRepo) find(uuid string, deleted bool) (entity.Category, error) {
+	category, ok := r.categories[uuid]
+	if !ok || r.deleted[uuid] != deleted {
+		return entity.Category{}, apperror.ErrNotFound
+	}
+	return category, nil
+}
+
+func (r *fakeCategoryRepo) FindByUUID(_ context.Context, uuid string) (entity.Category, error) {
+	return r.find(uuid, false)
+}
+
+func (r *fakeCategoryRepo) FindDeletedByUUID(_ context.Context, uuid string) (entity.Category, error) {
+	return r.find(uuid, true)
+}
+
+func (r *fakeCategoryRepo) HasChildren(context.Context, string) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeCategoryRepo) HasOperations(context.Context, string) (bool, error) {
+	return len(r.operations) > 0, nil
+}
+
+func (r *fakeCategoryRepo) ReassignOperations(ctx context.Context, _, toUUID string) ([]entity.Operation, error) {
+	if !inTransaction(ctx) {
+		return nil, errOutsideTransaction
+	}
+	operations := make([]entity.Operation, 0, len(r.operations))
+	for _, operation := range r.operations {
+		operation.CategoryUUID = toUUID
+		operations = append(operations, operation)
+	}
+	return operations, nil
+}
+
+func (r *fakeCategoryRepo) DeleteOperations(ctx context.Context, _ string) ([]entity.Operation, error) {
+	if !inTransaction(ctx) {
+		return nil, errOutsideTransaction
+	}
+	return r.operations, nil
+}
+
+func (r *fakeCategoryRepo) Delete(ctx context.Context, uuid string) error {
+	if !inTransaction(ctx) {
+		return errOutsideTransaction
+	}
+	r.deleted[uuid] = true
+	return nil
+}
+
+func (r *fakeCategoryRepo) Restore(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	if !inTransaction(ctx) {
+		return nil, errOutsideTransaction
+	}
+	r.deleted[uuid] = false
+	return r.operations, nil
+}
+
+func TestCategoryServiceDelete(t *testing.T) {
+	category := entity.Category{UUID: "c1", UserUUID: "u1", Type: "expense"}
+	target := entity.Category{UUID: "c3", UserUUID: "u1", Type: "expense"}
+	foreign := entity.Category{UUID: "c4", UserUUID: "u2", Type: "expense"}
+	income := entity.Category{UUID: "c5", UserUUID: "u1", Type: "income"}
+	operations := []entity.Operation{{UUID: "o1", CategoryUUID: "c1"}, {UUID: "o2", CategoryUUID: "c1"}}
+
+	tests := []struct {
+		name       string
+		user       string
+		dto        dto.DeleteCategoryDTO
+		operations []entity.Operation
+		wantStatus int
+		wantAudit  []string
+		wantEvents []string
+	}{
+		{name: "without operations", user: "u1", dto: dto.DeleteCategoryDTO{UUID: "c1"},
+			wantAudit: []string{"category c1 delete"}, wantEvents: []string{"category.deleted c1"}},
+		{name: "cascade audits every operation", user: "u1", dto: dto.DeleteCategoryDTO{UUID: "c1", Cascade: true},
+			operations: operations,
+			wantAudit:  []string{"operation o1 delete", "operation o2 delete", "category c1 delete"},
+			wantEvents: []string{"category.deleted c1"}},
+		{name: "reassign audits every operation", user: "u1", dto: dto.DeleteCategoryDTO{UUID: "c1", ReassignTo: "c3"},
+			operations: operations,
+			wantAudit:  []string{"operation o1 update", "operation o2 update", "category c1 delete"},
+			wantEvents: []string{"category.deleted c1"}},
+		{name: "with operations", user: "u1", dto: dto.DeleteCategoryDTO{UUID: "c1"}, operations: operations,
+			wantStatus: http.StatusBadRequest},
+		{name: "reassign to itself", user: "u1", dto: dto.DeleteCategoryDTO{UUID: "c1", ReassignTo: "c1"},
+			operations: operations, wantStatus: http.StatusBadRequest},
+		{name: "reassign to category of another user", user: "u1",
+			dto: dto.DeleteCategoryDTO{UUID: "c1", ReassignTo: "c4"}, operations: operations,
+			wantStatus: http.StatusBadRequest},
+		{name: "reassign to category of another type", user: "u1",
+			dto: dto.DeleteCategoryDTO{UUID: "c1", ReassignTo: "c5"}, operations: operations,
+			wantStatus: http.StatusBadRequest},
+		{name: "category of another user", user: "u2", dto: dto.DeleteCategoryDTO{UUID: "c1", Cascade: true},
+			operations: operations, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeCategoryRepo(tt.operations, category, target, foreign, income)
+			outboxRepo, auditRepo := &fakeOutboxRepo{}, &fakeAuditRepo{}
+			s := NewCategoryService(repo, outboxRepo, auditRepo, fakeTransactor{}, newTestLogger())
+
+			err := s.Delete(userContext(tt.user), tt.dto)
+			if status := errorStatus(err); status != tt.wantStatus {
+				t.Fatalf("Delete() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if got := auditRepo.records(); !equalStrings(got, tt.wantAudit) {
+				t.Errorf("audit records = %v, want %v", got, tt.wantAudit)
+			}
+			if got := outboxRepo.events(); !equalStrings(got, tt.wantEvents) {
+				t.Errorf("outbox events = %v, want %v", got, tt.wantEvents)
+			}
+			if repo.deleted["c1"] != (tt.wantStatus == 0) {
+				t.Errorf("category deleted = %t, want %t", repo.deleted["c1"], tt.wantStatus == 0)
+			}
+			// moved operations are audited with their category before and after the move
+			for _, record := range auditRepo.saved {
+				if record.Action != entity.UpdateAuditAction {
+					continue
+				}
+				change := record.Changes["category_uuid"]
+				if string(change.Before) != `"c1"` || string(change.After) != `"c3"` {
+					t.Errorf("operation %s category change = %s -> %s, want \"c1\" -> \"c3\"", record.EntityUUID,
+						change.Before, change.After)
+				}
+			}
+		})
+	}
+}
+
+func TestCategoryServiceRestore(t *testing.T) {
+	category := entity.Category{UUID: "c1", UserUUID: "u1", Type: "expense"}
+	child := entity.Category{UUID: "c2", UserUUID: "u1", Type: "expense", ParentUUID: "c1"}
+	operations := []entity.Operation{{UUID: "o1", CategoryUUID: "c1"}, {UUID: "o2", CategoryUUID: "c1"}}
+
+	tests := []struct {
+		name       string
+		user       string
+		uuid       string
+		operations []entity.Operation
+		wantStatus int
+		wantAudit  []string
+		wantEvents []string
+	}{
+		{name: "without operations", user: "u1", uuid: "c1",
+			wantAudit: []string{"category c1 restore"}, wantEvents: []string{"category.restored c1"}},
+		{name: "restored operations are audited", user: "u1", uuid: "c1", operations: operations,
+			wantAudit:  []string{"operation o1 restore", "operation o2 restore", "category c1 restore"},
+			wantEvents: []string{"category.restored c1"}},
+		{name: "category of another user", user: "u2", uuid: "c1", operations: operations,
+			wantStatus: http.StatusNotFound},
+		{name: "deleted parent", user: "u1", uuid: "c2", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeCategoryRepo(tt.operations, category, child)
+			repo.deleted["c1"], repo.deleted["c2"] = true, true
+			outboxRepo, auditRepo := &fakeOutboxRepo{}, &fakeAuditRepo{}
+			s := NewCategoryService(repo, outboxRepo, auditRepo, fakeTransactor{}, newTestLogger())
+
+			err := s.Restore(userContext(tt.user), tt.uuid)
+			if status := errorStatus(err); status != tt.wantStatus {
+				t.Fatalf("Restore() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if got := auditRepo.records(); !equalStrings(got, tt.wantAudit) {
+				t.Errorf("audit records = %v, want %v", got, tt.wantAudit)
+			}
+			if got := outboxRepo.events(); !equalStrings(got, tt.wantEvents) {
+				t.Errorf("outbox events = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
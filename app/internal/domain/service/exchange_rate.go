@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"time"
+)
+
+// ExchangeRateProvider gives rate to convert money from base to quote currency on a date.
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, base, quote types.Currency, date time.Time) (types.Rate, error)
+}
+
+type ExchangeRateRepo interface {
+	ExchangeRateProvider
+	Save(ctx context.Context, rates []entity.ExchangeRate) error
+}
+
+type exchangeRateService struct {
+	repository ExchangeRateRepo
+	logger     *logging.Logger
+}
+
+func NewExchangeRateService(repository ExchangeRateRepo, logger *logging.Logger) controller.ExchangeRateService {
+	return &exchangeRateService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *exchangeRateService) Save(ctx context.Context, dtos []dto.CreateExchangeRateDTO) error {
+	if err := checkAdmin(ctx); err != nil {
+		return err
+	}
+
+	// the same rate given twice is saved once, the last one wins
+	type rateKey struct {
+		base, quote types.Currency
+		date        time.Time
+	}
+	index := make(map[rateKey]int, len(dtos))
+	rates := make([]entity.ExchangeRate, 0, len(dtos))
+
+	for i, rateDTO := range dtos {
+		date, err := time.Parse(entity.DateLayout, rateDTO.Date)
+		if err != nil {
+			return apperror.ValidationError(fmt.Sprintf("rate %d: date must be in YYYY-MM-DD format", i+1))
+		}
+
+		rate := entity.NewExchangeRate(rateDTO, date)
+		key := rateKey{base: rate.Base, quote: rate.Quote, date: rate.Date}
+		if j, ok := index[key]; ok {
+			rates[j] = *rate
+			continue
+		}
+		index[key] = len(rates)
+		rates = append(rates, *rate)
+	}
+
+	err := s.repository.Save(ctx, rates)
+	if err != nil {
+		return fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+	return nil
+}
+
+func (s *exchangeRateService) Get(ctx context.Context, dto dto.GetExchangeRateDTO) (entity.ExchangeRate, error) {
+	date := time.Now()
+	if dto.Date != nil {
+		date = *dto.Date
+	}
+
+	rate, err := rateOnDate(ctx, s.repository, dto.Base, dto.Quote, date)
+	if err != nil {
+		return entity.ExchangeRate{}, err
+	}
+
+	return entity.ExchangeRate{
+		Base:  dto.Base,
+		Quote: dto.Quote,
+		Rate:  rate,
+		Date:  date,
+	}, nil
+}
+
+// rateOnDate asks provider for a rate and reports a missing rate as a client error.
+func rateOnDate(ctx context.Context, provider ExchangeRateProvider, base, quote types.Currency,
+	date time.Time) (types.Rate, error) {
+	rate, err := provider.Rate(ctx, base, quote, date)
+	if err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return types.Rate{}, apperror.ValidationError(fmt.Sprintf("exchange rate from %s to %s on %s is unknown",
+				base, quote, date.Format(entity.DateLayout)))
+		}
+		return types.Rate{}, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return rate, nil
+}
+
+// converter converts money to a single currency, caching rates for the duration of a request.
+type converter struct {
+	provider ExchangeRateProvider
+	to       types.Currency
+	rates    map[converterKey]types.Rate
+}
+
+type converterKey struct {
+	from types.Currency
+	date string
+}
+
+func newConverter(provider ExchangeRateProvider, to types.Currency) *converter {
+	return &converter{
+		provider: provider,
+		to:       to,
+		rates:    make(map[converterKey]types.Rate),
+	}
+}
+
+func (c *converter) convert(ctx context.Context, m types.Money, from types.Currency,
+	date time.Time) (types.Money, error) {
+	key := converterKey{from: from, date: date.Format(entity.DateLayout)}
+	rate, ok := c.rates[key]
+	if !ok {
+		var err error
+		rate, err = rateOnDate(ctx, c.provider, from, c.to, date)
+		if err != nil {
+			return types.Money{}, err
+		}
+		c.rates[key] = rate
+	}
+	return m.Convert(rate), nil
+}
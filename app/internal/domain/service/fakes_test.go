@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/sirupsen/logrus"
+	"io"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/requestctx"
+	"time"
+)
+
+func newTestLogger() *logging.Logger {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	return &logging.Logger{Entry: logrus.NewEntry(l)}
+}
+
+// userContext returns the context of a request made by the user.
+func userContext(userUUID string) context.Context {
+	return requestctx.WithUserUUID(context.Background(), userUUID)
+}
+
+type txKey struct{}
+
+// errOutsideTransaction is returned by fake repositories for writes which must be made
+// within a transaction but are not.
+var errOutsideTransaction = errors.New("write outside of transaction")
+
+func inTransaction(ctx context.Context) bool {
+	tx, _ := ctx.Value(txKey{}).(bool)
+	return tx
+}
+
+// fakeTransactor marks the context passed to fn, so fake repositories can check that
+// their writes are made within the transaction.
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+type fakeAuditRepo struct {
+	AuditRepo
+	saved []entity.AuditRecord
+}
+
+func (r *fakeAuditRepo) Save(ctx context.Context, record entity.AuditRecord) error {
+	if !inTransaction(ctx) {
+		return errOutsideTransaction
+	}
+	r.saved = append(r.saved, record)
+	return nil
+}
+
+// records returns "entity uuid action" of the saved records in the order of saving.
+func (r *fakeAuditRepo) records() []string {
+	records := make([]string, 0, len(r.saved))
+	for _, record := range r.saved {
+		records = append(records, record.EntityType+" "+record.EntityUUID+" "+record.Action)
+	}
+	return records
+}
+
+type fakeOutboxRepo struct {
+	saved     []entity.OutboxEvent
+	claimable []entity.OutboxEvent
+	published []string
+	released  []string
+}
+
+func (r *fakeOutboxRepo) Save(ctx context.Context, event entity.OutboxEvent) error {
+	if !inTransaction(ctx) {
+		return errOutsideTransaction
+	}
+	r.saved = append(r.saved, event)
+	return nil
+}
+
+func (r *fakeOutboxRepo) Claim(_ context.Context, limit int, _ time.Duration) ([]entity.OutboxEvent, error) {
+	n := min(limit, len(r.claimable))
+	events := r.claimable[:n]
+	r.claimable = r.claimable[n:]
+	return events, nil
+}
+
+func (r *fakeOutboxRepo) MarkPublished(_ context.Context, uuids []string) error {
+	r.published = append(r.published, uuids...)
+	return nil
+}
+
+func (r *fakeOutboxRepo) Release(_ context.Context, uuids []string) error {
+	r.released = append(r.released, uuids...)
+	return nil
+}
+
+// events returns "event aggregate" of the saved events in the order of saving.
+func (r *fakeOutboxRepo) events() []string {
+	events := make([]string, 0, len(r.saved))
+	for _, event := range r.saved {
+		events = append(events, event.Event+" "+event.AggregateUUID)
+	}
+	return events
+}
+
+type fakePublisher struct {
+	failOn    string
+	published []string
+}
+
+func (p *fakePublisher) Publish(_ context.Context, event entity.OutboxEvent) error {
+	if event.UUID == p.failOn {
+		return errors.New("unavailable")
+	}
+	p.published = append(p.published, event.UUID)
+	return nil
+}
+
+// errorStatus returns the HTTP status of the app error, 0 for nil and -1 for other errors.
+func errorStatus(err error) int {
+	if err == nil {
+		return 0
+	}
+	var appErr *apperror.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Status
+	}
+	return -1
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"operation-service/internal/apperror"
 	"operation-service/internal/controller/dto"
@@ -9,51 +10,95 @@ import (
 	"operation-service/internal/domain/entity"
 	"operation-service/internal/domain/types"
 	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"time"
 )
 
+var errTransferOperation = apperror.ValidationError("operation is a part of transfer and is changed with the transfer")
+
 type OperationRepo interface {
 	Create(ctx context.Context, operation entity.Operation) (string, error)
 	FindByUUID(ctx context.Context, uuid string) (entity.Operation, error)
+	FindByTransferUUID(ctx context.Context, uuid string) ([]entity.Operation, error)
+	Find(ctx context.Context, filter entity.OperationFilter) ([]entity.Operation, error)
+	Balance(ctx context.Context, filter entity.BalanceFilter) ([]entity.Balance, error)
+	DailyBalance(ctx context.Context, filter entity.BalanceFilter) ([]entity.DailyBalance, error)
+	SumByCategory(ctx context.Context, filter entity.ReportFilter) ([]entity.CategoryReportRow, error)
 	Update(ctx context.Context, operation entity.Operation) error
 	Delete(ctx context.Context, uuid string) error
+	FindDeletedByUUID(ctx context.Context, uuid string) (entity.Operation, error)
+	Restore(ctx context.Context, uuid string) error
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 type operationService struct {
 	operationRepo OperationRepo
 	categoryRepo  CategoryRepo
+	accountRepo   AccountRepo
+	outboxRepo    OutboxRepo
+	auditRepo     AuditRepo
+	rateProvider  ExchangeRateProvider
+	budgetAlerter *BudgetAlerter
+	transactor    Transactor
 	logger        *logging.Logger
 }
 
-func NewOperationService(operationRepo OperationRepo, categoryRepo CategoryRepo,
-	logger *logging.Logger) controller.OperationService {
+func NewOperationService(operationRepo OperationRepo, categoryRepo CategoryRepo, accountRepo AccountRepo,
+	outboxRepo OutboxRepo, auditRepo AuditRepo, rateProvider ExchangeRateProvider, budgetAlerter *BudgetAlerter,
+	transactor Transactor, logger *logging.Logger) controller.OperationService {
 	return &operationService{
 		operationRepo: operationRepo,
 		categoryRepo:  categoryRepo,
+		accountRepo:   accountRepo,
+		outboxRepo:    outboxRepo,
+		auditRepo:     auditRepo,
+		rateProvider:  rateProvider,
+		budgetAlerter: budgetAlerter,
+		transactor:    transactor,
 		logger:        logger,
 	}
 }
 
 func (s *operationService) Create(ctx context.Context, dto dto.CreateOperationDTO) (string, error) {
-	if dto.MoneySum <= 0 {
-		return "", apperror.BadRequestError("money sum can not be negative or zero")
-	}
-
 	category, err := s.categoryRepo.FindByUUID(ctx, dto.CategoryUUID)
 	if err != nil {
 		return "", err
 	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return "", err
+	}
 
 	operation := entity.NewOperation(dto)
+	operation.UserUUID = category.UserUUID
+
+	if err = s.resolveCurrency(ctx, operation, category); err != nil {
+		return "", err
+	}
 
 	if category.Type == types.ExpenseType {
-		operation.MoneySum = -operation.MoneySum
+		operation.MoneySum = operation.MoneySum.Neg()
 	}
 
-	operationUUID, err := s.operationRepo.Create(ctx, *operation)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		operationUUID, err := s.operationRepo.Create(ctx, *operation)
+		if err != nil {
+			return err
+		}
+		operation.UUID = operationUUID
+
+		err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID, entity.CreateAuditAction,
+			nil, operation)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.OperationCreatedEvent, operation.UUID, operation)
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to create operation: %w", err)
 	}
-	return operationUUID, nil
+
+	s.budgetAlerter.Check(ctx, operation.CategoryUUID)
+	return operation.UUID, nil
 }
 
 func (s *operationService) GetByUUID(ctx context.Context, uuid string) (entity.Operation, error) {
@@ -61,42 +106,260 @@ func (s *operationService) GetByUUID(ctx context.Context, uuid string) (entity.O
 	if err != nil {
 		return operation, fmt.Errorf("failed to find operation by uuid: %w", err)
 	}
+	if err = checkOwner(ctx, operation.UserUUID); err != nil {
+		return entity.Operation{}, err
+	}
 	return operation, nil
 }
 
-func (s *operationService) Update(ctx context.Context, dto dto.UpdateOperationDTO) error {
-	if dto.MoneySum < 0 {
-		return apperror.BadRequestError("money sum can not be negative")
+func (s *operationService) GetByFilter(ctx context.Context,
+	dto dto.FindOperationsDTO) (pagination.Page[entity.Operation], error) {
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return pagination.Page[entity.Operation]{}, err
+	}
+	if dto.UserUUID != "" && dto.UserUUID != userUUID {
+		return pagination.Page[entity.Operation]{}, apperror.ErrNotFound
+	}
+	// operations of categories of other users are filtered out by the user
+	dto.UserUUID = userUUID
+
+	filter := entity.NewOperationFilter(dto)
+	operations, err := s.operationRepo.Find(ctx, *filter)
+	if err != nil {
+		return pagination.Page[entity.Operation]{}, fmt.Errorf("failed to find operations: %w", err)
 	}
+	return pagination.NewPage(operations, filter.Limit, entity.Operation.Cursor), nil
+}
 
+func (s *operationService) GetBalance(ctx context.Context, dto dto.GetBalanceDTO) ([]entity.Balance, error) {
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dto.UserUUID != "" && dto.UserUUID != userUUID {
+		return nil, apperror.ErrNotFound
+	}
+	dto.UserUUID = userUUID
+
+	filter := entity.NewBalanceFilter(dto)
+	if dto.Currency != "" {
+		return s.convertedBalance(ctx, *filter, dto.Currency)
+	}
+
+	balances, err := s.operationRepo.Balance(ctx, *filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+	return balances, nil
+}
+
+// convertedBalance sums daily balances converted to currency at the rate of their day.
+func (s *operationService) convertedBalance(ctx context.Context, filter entity.BalanceFilter,
+	currency types.Currency) ([]entity.Balance, error) {
+	dailyBalances, err := s.operationRepo.DailyBalance(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate daily balance: %w", err)
+	}
+
+	conv := newConverter(s.rateProvider, currency)
+	total := entity.Balance{Currency: currency}
+	for _, daily := range dailyBalances {
+		income, err := conv.convert(ctx, daily.Income, daily.Currency, daily.Day)
+		if err != nil {
+			return nil, err
+		}
+		expense, err := conv.convert(ctx, daily.Expense, daily.Currency, daily.Day)
+		if err != nil {
+			return nil, err
+		}
+		total.Income = total.Income.Add(income)
+		total.Expense = total.Expense.Add(expense)
+	}
+	total.Net = total.Income.Sub(total.Expense)
+
+	return []entity.Balance{total}, nil
+}
+
+func (s *operationService) Update(ctx context.Context, dto dto.UpdateOperationDTO) error {
 	operation, err := s.operationRepo.FindByUUID(ctx, dto.UUID)
 	if err != nil {
 		return fmt.Errorf("failed to find operation by uuid: %w", err)
 	}
+	if err = checkOwner(ctx, operation.UserUUID); err != nil {
+		return err
+	}
+	if operation.TransferUUID != "" {
+		return errTransferOperation
+	}
 
 	updOperation := entity.UpdatedOperation(operation, dto)
 
-	_, err = s.categoryRepo.FindByUUID(ctx, updOperation.CategoryUUID)
+	category, err := s.categoryRepo.FindByUUID(ctx, updOperation.CategoryUUID)
 	if err != nil {
 		return err
 	}
+	// operation can not be moved into a category of another user
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return err
+	}
+
+	// the currency of the new account is taken unless the currency is given explicitly
+	if dto.AccountUUID != "" && dto.Currency == "" {
+		updOperation.Currency = ""
+	}
+	if err = s.resolveCurrency(ctx, updOperation, category); err != nil {
+		return err
+	}
 
-	err = s.operationRepo.Update(ctx, *updOperation)
+	// the sum may come from the request as positive or be kept from an operation of another category type
+	updOperation.MoneySum = updOperation.MoneySum.Abs()
+	if category.Type == types.ExpenseType {
+		updOperation.MoneySum = updOperation.MoneySum.Neg()
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.operationRepo.Update(ctx, *updOperation); err != nil {
+			return err
+		}
+		err := saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, updOperation.UUID, entity.UpdateAuditAction,
+			operation, updOperation)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.OperationUpdatedEvent, updOperation.UUID, updOperation)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update operation: %w", err)
 	}
+
+	s.budgetAlerter.Check(ctx, updOperation.CategoryUUID)
 	return nil
 }
 
 func (s *operationService) Delete(ctx context.Context, uuid string) error {
-	_, err := s.operationRepo.FindByUUID(ctx, uuid)
+	operation, err := s.operationRepo.FindByUUID(ctx, uuid)
 	if err != nil {
 		return err
 	}
+	if err = checkOwner(ctx, operation.UserUUID); err != nil {
+		return err
+	}
+	if operation.TransferUUID != "" {
+		return errTransferOperation
+	}
 
-	err = s.operationRepo.Delete(ctx, uuid)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.operationRepo.Delete(ctx, uuid); err != nil {
+			return err
+		}
+		err := saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID, entity.DeleteAuditAction,
+			operation, nil)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.OperationDeletedEvent, operation.UUID, operation)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete operation by uuid: %w", err)
 	}
+
+	s.budgetAlerter.Check(ctx, operation.CategoryUUID)
+	return nil
+}
+
+// GetHistory returns changes of the operation starting from the latest one. History of
+// deleted operations is available until they are purged.
+func (s *operationService) GetHistory(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.AuditRecord], error) {
+	operation, err := s.operationRepo.FindByUUID(ctx, uuid)
+	if errors.Is(err, apperror.ErrNotFound) {
+		operation, err = s.operationRepo.FindDeletedByUUID(ctx, uuid)
+	}
+	if err != nil {
+		return pagination.Page[entity.AuditRecord]{}, err
+	}
+	if err = checkOwner(ctx, operation.UserUUID); err != nil {
+		return pagination.Page[entity.AuditRecord]{}, err
+	}
+
+	records, err := s.auditRepo.FindByEntity(ctx, entity.OperationAuditEntity, uuid, page)
+	if err != nil {
+		return pagination.Page[entity.AuditRecord]{}, fmt.Errorf("failed to find operation history: %w", err)
+	}
+	return pagination.NewPage(records, page.Limit, entity.AuditRecord.Cursor), nil
+}
+
+// Restore restores deleted operation unless its category is deleted too.
+func (s *operationService) Restore(ctx context.Context, uuid string) error {
+	operation, err := s.operationRepo.FindDeletedByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, operation.UserUUID); err != nil {
+		return err
+	}
+	if operation.TransferUUID != "" {
+		return errTransferOperation
+	}
+
+	_, err = s.categoryRepo.FindByUUID(ctx, operation.CategoryUUID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.ValidationError("category of the operation is deleted and must be restored first")
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.operationRepo.Restore(ctx, uuid); err != nil {
+			return err
+		}
+		err := saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID, entity.RestoreAuditAction,
+			nil, operation)
+		if err != nil {
+			return err
+		}
+		return saveEvent(ctx, s.outboxRepo, entity.OperationRestoredEvent, operation.UUID, operation)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore operation by uuid: %w", err)
+	}
+
+	s.budgetAlerter.Check(ctx, operation.CategoryUUID)
+	return nil
+}
+
+// resolveCurrency checks that operation's account belongs to the category's user and sets
+// the operation currency to the account's or, without account, to the category's default one.
+func (s *operationService) resolveCurrency(ctx context.Context, operation *entity.Operation,
+	category entity.Category) error {
+	if operation.AccountUUID != "" {
+		account, err := s.accountRepo.FindByUUID(ctx, operation.AccountUUID)
+		if err != nil {
+			return err
+		}
+		if account.UserUUID != category.UserUUID {
+			return apperror.FieldValidationError("account_uuid", apperror.CodeMismatch,
+				"account and category must belong to the same user")
+		}
+
+		if operation.Currency == "" {
+			operation.Currency = account.Currency
+		}
+		if operation.Currency != account.Currency {
+			return apperror.FieldValidationError("currency", apperror.CodeMismatch,
+				"operation currency must match account currency")
+		}
+		return nil
+	}
+
+	if operation.Currency == "" {
+		operation.Currency = category.Currency
+	}
+	if operation.Currency == "" {
+		return apperror.FieldValidationError("currency", apperror.CodeRequired,
+			"currency must be specified as category has no default currency")
+	}
 	return nil
 }
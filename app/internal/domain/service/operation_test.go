@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"testing"
+)
+
+type fakeOperationRepo struct {
+	OperationRepo
+	operations map[string]entity.Operation
+	deleted    map[string]bool
+	updated    []entity.Operation
+}
+
+func newFakeOperationRepo(operations ...entity.Operation) *fakeOperationRepo {
+	r := &fakeOperationRepo{
+		operations: make(map[string]entity.Operation),
+		deleted:    make(map[string]bool),
+	}
+	for _, operation := range operations {
+		r.operations[operation.UUID] = operation
+	}
+	return r
+}
+
+func (r *fakeOperationRepo) find(uuid string, deleted bool) (entity.Operation, error) {
+	operation, ok := r.operations[uuid]
+	if !ok || r.deleted[uuid] != deleted {
+		return entity.Operation{}, apperror.ErrNotFound
+	}
+	return operation, nil
+}
+
+func (r *fakeOperationRepo) Create(ctx context.Context, operation entity.Operation) (string, error) {
+	if !inTransaction(ctx) {
+		return "", errOutsideTransaction
+	}
+	operation.UUID = "new"
+	r.operations[operation.UUID] = operation
+	return operation.UUID, nil
+}
+
+func (r *fakeOperationRepo) FindByUUID(_ context.Context, uuid string) (entity.Operation, error) {
+	return r.find(uuid, false)
+}
+
+func (r *fakeOperationRepo) FindDeletedByUUID(_ context.Context, uuid string) (entity.Operation, error) {
+	return r.find(uuid, true)
+}
+
+func (r *fakeOperationRepo) Update(ctx context.Context, operation entity.Operation) error {
+	if !inTransaction(ctx) {
+		return errOutsideTransaction
+	}
+	r.updated = append(r.updated, operation)
+	return nil
+}
+
+func (r *fakeOperationRepo) Delete(ctx context.Context, uuid string) error {
+	if !inTransaction(ctx) {
+		return errOutsideTransaction
+	}
+	r.deleted[uuid] = true
+	return nil
+}
+
+func (r *fakeOperationRepo) Restore(ctx context.Context, uuid string) error {
+	if !inTransaction(ctx) {
+		return errOutsideTransaction
+	}
+	r.deleted[uuid] = false
+	return nil
+}
+
+// fakeBudgetRepo has no budgets, so budget alerts are never checked further.
+type fakeBudgetRepo struct {
+	BudgetRepo
+}
+
+func (fakeBudgetRepo) FindByCategoryUUID(context.Context, string) ([]entity.Budget, error) {
+	return nil, nil
+}
+
+type operationServiceTest struct {
+	operationRepo *fakeOperationRepo
+	outboxRepo    *fakeOutboxRepo
+	auditRepo     *fakeAuditRepo
+	service       *operationService
+}
+
+// newOperationServiceTest creates the service for categories c1 of user u1 and c2 of user u2,
+// accounts a1 of user u1 and a2 of user u2 and the given operations.
+func newOperationServiceTest(operations ...entity.Operation) operationServiceTest {
+	categoryRepo := newFakeCategoryRepo(nil,
+		entity.Category{UUID: "c1", UserUUID: "u1", Type: types.ExpenseType, Currency: "USD"},
+		entity.Category{UUID: "c2", UserUUID: "u2", Type: types.ExpenseType, Currency: "USD"})
+	accountRepo := newFakeAccountRepo(
+		entity.Account{UUID: "a1", UserUUID: "u1", Currency: "USD"},
+		entity.Account{UUID: "a2", UserUUID: "u2", Currency: "USD"})
+	test := operationServiceTest{
+		operationRepo: newFakeOperationRepo(operations...),
+		outboxRepo:    &fakeOutboxRepo{},
+		auditRepo:     &fakeAuditRepo{},
+	}
+	alerter := NewBudgetAlerter(fakeBudgetRepo{}, nil, test.operationRepo, nil, fakeTransactor{}, newTestLogger())
+	test.service = NewOperationService(test.operationRepo, categoryRepo, accountRepo, test.outboxRepo,
+		test.auditRepo, nil, alerter, fakeTransactor{}, newTestLogger()).(*operationService)
+	return test
+}
+
+func TestOperationServiceCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		dto        dto.CreateOperationDTO
+		wantStatus int
+		wantSum    int64
+		wantAudit  []string
+		wantEvents []string
+	}{
+		{name: "expense in own category", ctx: userContext("u1"),
+			dto:     dto.CreateOperationDTO{CategoryUUID: "c1", MoneySum: types.MoneyFromMinor(1230)},
+			wantSum: -1230, wantAudit: []string{"operation new create"}, wantEvents: []string{"operation.created new"}},
+		{name: "own account", ctx: userContext("u1"),
+			dto:     dto.CreateOperationDTO{CategoryUUID: "c1", AccountUUID: "a1", MoneySum: types.MoneyFromMinor(5)},
+			wantSum: -5, wantAudit: []string{"operation new create"}, wantEvents: []string{"operation.created new"}},
+		{name: "category of another user", ctx: userContext("u1"),
+			dto:        dto.CreateOperationDTO{CategoryUUID: "c2", MoneySum: types.MoneyFromMinor(1230)},
+			wantStatus: http.StatusNotFound},
+		{name: "account of another user", ctx: userContext("u1"),
+			dto: dto.CreateOperationDTO{CategoryUUID: "c1", AccountUUID: "a2",
+				MoneySum: types.MoneyFromMinor(1)},
+			wantStatus: http.StatusBadRequest},
+		{name: "unauthenticated", ctx: context.Background(),
+			dto:        dto.CreateOperationDTO{CategoryUUID: "c1", MoneySum: types.MoneyFromMinor(1230)},
+			wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := newOperationServiceTest()
+
+			_, err := test.service.Create(tt.ctx, tt.dto)
+			if status := errorStatus(err); status != tt.wantStatus {
+				t.Fatalf("Create() error = %v, want status %d", err, tt.wantStatus)
+			}
+			if created, ok := test.operationRepo.operations["new"]; ok != (tt.wantStatus == 0) {
+				t.Fatalf("operation created = %t, want %t", ok, tt.wantStatus == 0)
+			} else if ok && created.MoneySum.Minor() != tt.wantSum {
+				t.Errorf("created money sum = %d, want %d", created.MoneySum.Minor(), tt.wantSum)
+			}
+			if got := test.auditRepo.records(); !equalStrings(got, tt.wantAudit) {
+				t.Errorf("audit records = %v, want %v", got, tt.wantAudit)
+			}
+			if got := test.outboxRepo.events(); !equalStrings(got, tt.wantEvents) {
+				t.Errorf("outbox events = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
+
+func TestOperationServiceOwnership(t *testing.T) {
+	own := entity.Operation{UUID: "o1", UserUUID: "u1", CategoryUUID: "c1", MoneySum: types.MoneyFromMinor(-100),
+		Currency: "USD"}
+	foreign := entity.Operation{UUID: "o2", UserUUID: "u2", CategoryUUID: "c2", MoneySum: types.MoneyFromMinor(-100),
+		Currency: "USD"}
+	leg := entity.Operation{UUID: "o3", UserUUID: "u1", AccountUUID: "a1", TransferUUID: "t1",
+		MoneySum: types.MoneyFromMinor(-100), Currency: "USD"}
+
+	get := func(s *operationService, uuid string) error {
+		_, err := s.GetByUUID(userContext("u1"), uuid)
+		return err
+	}
+	update := func(categoryUUID string) func(s *operationService, uuid string) error {
+		return func(s *operationService, uuid string) error {
+			return s.Update(userContext("u1"), dto.UpdateOperationDTO{UUID: uuid, CategoryUUID: categoryUUID})
+		}
+	}
+	remove := func(s *operationService, uuid string) error {
+		return s.Delete(userContext("u1"), uuid)
+	}
+	restore := func(s *operationService, uuid string) error {
+		return s.Restore(userContext("u1"), uuid)
+	}
+
+	tests := []struct {
+		name       string
+		call       func(s *operationService, uuid string) error
+		uuid       string
+		deleted    bool
+		wantStatus int
+		wantAudit  []string
+		wantEvents []string
+	}{
+		{name: "get own operation", call: get, uuid: "o1"},
+		{name: "get operation of another user", call: get, uuid: "o2", wantStatus: http.StatusNotFound},
+		{name: "update own operation", call: update("c1"), uuid: "o1",
+			wantAudit: []string{"operation o1 update"}, wantEvents: []string{"operation.updated o1"}},
+		{name: "update operation of another user", call: update("c2"), uuid: "o2", wantStatus: http.StatusNotFound},
+		{name: "move own operation into category of another user", call: update("c2"), uuid: "o1",
+			wantStatus: http.StatusNotFound},
+		{name: "update transfer operation", call: update("c1"), uuid: "o3", wantStatus: http.StatusBadRequest},
+		{name: "delete own operation", call: remove, uuid: "o1",
+			wantAudit: []string{"operation o1 delete"}, wantEvents: []string{"operation.deleted o1"}},
+		{name: "delete operation of another user", call: remove, uuid: "o2", wantStatus: http.StatusNotFound},
+		{name: "delete transfer operation", call: remove, uuid: "o3", wantStatus: http.StatusBadRequest},
+		{name: "restore own operation", call: restore, uuid: "o1", deleted: true,
+			wantAudit: []string{"operation o1 restore"}, wantEvents: []string{"operation.restored o1"}},
+		{name: "restore operation of another user", call: restore, uuid: "o2", deleted: true,
+			wantStatus: http.StatusNotFound},
+		{name: "restore transfer operation", call: restore, uuid: "o3", deleted: true,
+			wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := newOperationServiceTest(own, foreign, leg)
+			test.operationRepo.deleted[tt.uuid] = tt.deleted
+
+			err := tt.call(test.service, tt.uuid)
+			if status := errorStatus(err); status != tt.wantStatus {
+				t.Fatalf("error = %v, want status %d", err, tt.wantStatus)
+			}
+			changed := len(test.operationRepo.updated) > 0 || test.operationRepo.deleted[tt.uuid] != tt.deleted
+			if tt.wantStatus != 0 && changed {
+				t.Error("operation is changed")
+			}
+			if got := test.auditRepo.records(); !equalStrings(got, tt.wantAudit) {
+				t.Errorf("audit records = %v, want %v", got, tt.wantAudit)
+			}
+			if got := test.outboxRepo.events(); !equalStrings(got, tt.wantEvents) {
+				t.Errorf("outbox events = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
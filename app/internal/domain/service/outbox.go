@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"time"
+)
+
+type OutboxRepo interface {
+	Save(ctx context.Context, event entity.OutboxEvent) error
+	Claim(ctx context.Context, limit int, timeout time.Duration) ([]entity.OutboxEvent, error)
+	MarkPublished(ctx context.Context, uuids []string) error
+	Release(ctx context.Context, uuids []string) error
+}
+
+// EventPublisher delivers domain events to other services.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.OutboxEvent) error
+}
+
+// saveEvent writes the event about the aggregate to the outbox. It must be called within
+// the transaction which changes the aggregate.
+func saveEvent(ctx context.Context, outboxRepo OutboxRepo, event, aggregateUUID string, aggregate any) error {
+	outboxEvent, err := entity.NewOutboxEvent(event, aggregateUUID, aggregate)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event, err)
+	}
+	return outboxRepo.Save(ctx, *outboxEvent)
+}
+
+// OutboxRelay periodically publishes events from the outbox in the order they were saved.
+// Events are claimed for claimTimeout and published after the claim is committed, so no row locks
+// are held while publishing. An event is published at least once: it can be published again if
+// marking it fails or publishing the batch takes longer than claimTimeout.
+type OutboxRelay struct {
+	repository   OutboxRepo
+	publisher    EventPublisher
+	interval     time.Duration
+	claimTimeout time.Duration
+	batchSize    int
+	logger       *logging.Logger
+}
+
+func NewOutboxRelay(repository OutboxRepo, publisher EventPublisher, interval, claimTimeout time.Duration,
+	batchSize int, logger *logging.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		repository:   repository,
+		publisher:    publisher,
+		interval:     interval,
+		claimTimeout: claimTimeout,
+		batchSize:    batchSize,
+		logger:       logger,
+	}
+}
+
+// Run relays events at start and then every interval until ctx is done.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.relayAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayAll relays batches until the outbox is drained or publishing fails.
+func (r *OutboxRelay) relayAll(ctx context.Context) {
+	for {
+		published, err := r.relay(ctx)
+		if err != nil {
+			r.logger.Errorf("failed to relay outbox events: %v", err)
+			return
+		}
+		if published < r.batchSize {
+			return
+		}
+	}
+}
+
+// relay publishes a batch of events and returns the number of published ones. Publishing stops
+// at the first failed event, so later events are not published before it. The claim of the
+// unpublished events is released to retry them on the next run.
+func (r *OutboxRelay) relay(ctx context.Context) (int, error) {
+	events, err := r.repository.Claim(ctx, r.batchSize, r.claimTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	var published []string
+	var publishErr error
+	for i, event := range events {
+		if publishErr = r.publisher.Publish(ctx, event); publishErr != nil {
+			publishErr = fmt.Errorf("failed to publish event %s: %w", event.UUID, publishErr)
+			if err = r.repository.Release(ctx, eventUUIDs(events[i:])); err != nil {
+				r.logger.Errorf("failed to release outbox events: %v", err)
+			}
+			break
+		}
+		published = append(published, event.UUID)
+	}
+
+	// events published before the failed one are marked anyway
+	if len(published) > 0 {
+		if err = r.repository.MarkPublished(ctx, published); err != nil {
+			return 0, err
+		}
+	}
+	return len(published), publishErr
+}
+
+func eventUUIDs(events []entity.OutboxEvent) []string {
+	uuids := make([]string, 0, len(events))
+	for _, event := range events {
+		uuids = append(uuids, event.UUID)
+	}
+	return uuids
+}
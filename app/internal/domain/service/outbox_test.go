@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"operation-service/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+func TestOutboxRelay(t *testing.T) {
+	events := func(uuids ...string) []entity.OutboxEvent {
+		events := make([]entity.OutboxEvent, 0, len(uuids))
+		for _, uuid := range uuids {
+			events = append(events, entity.OutboxEvent{UUID: uuid})
+		}
+		return events
+	}
+
+	tests := []struct {
+		name         string
+		claimable    []entity.OutboxEvent
+		failOn       string
+		wantPublish  []string
+		wantMarked   []string
+		wantReleased []string
+	}{
+		{name: "all batches are published in order", claimable: events("1", "2", "3", "4", "5"),
+			wantPublish: []string{"1", "2", "3", "4", "5"}, wantMarked: []string{"1", "2", "3", "4", "5"}},
+		{name: "failure releases the rest of the batch", claimable: events("1", "2", "3", "4", "5"), failOn: "2",
+			wantPublish: []string{"1"}, wantMarked: []string{"1"}, wantReleased: []string{"2"}},
+		{name: "failure in a later batch", claimable: events("1", "2", "3", "4", "5"), failOn: "4",
+			wantPublish: []string{"1", "2", "3"}, wantMarked: []string{"1", "2", "3"}, wantReleased: []string{"4"}},
+		{name: "empty outbox", wantPublish: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeOutboxRepo{claimable: tt.claimable}
+			publisher := &fakePublisher{failOn: tt.failOn}
+			relay := NewOutboxRelay(repo, publisher, time.Minute, time.Minute, 2, newTestLogger())
+
+			relay.relayAll(context.Background())
+
+			if !equalStrings(publisher.published, tt.wantPublish) {
+				t.Errorf("published %v, want %v", publisher.published, tt.wantPublish)
+			}
+			if !equalStrings(repo.published, tt.wantMarked) {
+				t.Errorf("marked %v, want %v", repo.published, tt.wantMarked)
+			}
+			if !equalStrings(repo.released, tt.wantReleased) {
+				t.Errorf("released %v, want %v", repo.released, tt.wantReleased)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"operation-service/internal/apperror"
+	"operation-service/pkg/requestctx"
+)
+
+// currentUser returns the uuid of the user on whose behalf ctx works.
+func currentUser(ctx context.Context) (string, error) {
+	userUUID, ok := requestctx.UserUUID(ctx)
+	if !ok {
+		return "", apperror.ErrUnauthorized
+	}
+	return userUUID, nil
+}
+
+// checkAdmin allows changes of data shared by all users to administrators only.
+func checkAdmin(ctx context.Context) error {
+	if _, err := currentUser(ctx); err != nil {
+		return err
+	}
+	if !requestctx.IsAdmin(ctx) {
+		return apperror.ErrForbidden
+	}
+	return nil
+}
+
+// checkOwner reports resources of other users as not found, so that their existence is not disclosed.
+func checkOwner(ctx context.Context, ownerUUID string) error {
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if ownerUUID != userUUID {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
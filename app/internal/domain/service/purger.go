@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"operation-service/pkg/logging"
+	"time"
+)
+
+// Purger periodically removes operations, transfers and categories deleted longer than the retention period ago.
+type Purger struct {
+	operationRepo OperationRepo
+	transferRepo  TransferRepo
+	categoryRepo  CategoryRepo
+	transactor    Transactor
+	retention     time.Duration
+	interval      time.Duration
+	logger        *logging.Logger
+}
+
+func NewPurger(operationRepo OperationRepo, transferRepo TransferRepo, categoryRepo CategoryRepo,
+	transactor Transactor, retention, interval time.Duration, logger *logging.Logger) *Purger {
+	return &Purger{
+		operationRepo: operationRepo,
+		transferRepo:  transferRepo,
+		categoryRepo:  categoryRepo,
+		transactor:    transactor,
+		retention:     retention,
+		interval:      interval,
+		logger:        logger,
+	}
+}
+
+// Run purges at start and then every interval until ctx is done.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge(ctx, time.Now().Add(-p.retention))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge removes operations before transfers and categories as those referenced by operations are kept.
+func (p *Purger) purge(ctx context.Context, deletedBefore time.Time) {
+	operations, err := p.operationRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		p.logger.Errorf("failed to purge deleted operations: %v", err)
+		return
+	}
+
+	transfers, err := p.transferRepo.Purge(ctx, deletedBefore)
+	if err != nil {
+		p.logger.Errorf("failed to purge deleted transfers: %v", err)
+		return
+	}
+
+	var categories int64
+	err = p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		categories, err = p.categoryRepo.Purge(ctx, deletedBefore)
+		return err
+	})
+	if err != nil {
+		p.logger.Errorf("failed to purge deleted categories: %v", err)
+		return
+	}
+
+	if operations > 0 || transfers > 0 || categories > 0 {
+		p.logger.Infof("purged %d operations, %d transfers and %d categories deleted before %s", operations,
+			transfers, categories, deletedBefore.Format(time.RFC3339))
+	}
+}
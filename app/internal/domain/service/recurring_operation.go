@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"time"
+)
+
+type RecurringOperationRepo interface {
+	Create(ctx context.Context, recurring entity.RecurringOperation) (string, error)
+	FindByUUID(ctx context.Context, uuid string) (entity.RecurringOperation, error)
+	FindDueForUpdate(ctx context.Context, uuid string, now time.Time) (entity.RecurringOperation, error)
+	FindByUserUUID(ctx context.Context, uuid string, page pagination.Params) ([]entity.RecurringOperation, error)
+	FindDue(ctx context.Context, now time.Time) ([]string, error)
+	UpdateSchedule(ctx context.Context, recurring entity.RecurringOperation) error
+	Delete(ctx context.Context, uuid string) error
+}
+
+type recurringOperationService struct {
+	repository   RecurringOperationRepo
+	categoryRepo CategoryRepo
+	accountRepo  AccountRepo
+	logger       *logging.Logger
+}
+
+func NewRecurringOperationService(repository RecurringOperationRepo, categoryRepo CategoryRepo,
+	accountRepo AccountRepo, logger *logging.Logger) controller.RecurringOperationService {
+	return &recurringOperationService{
+		repository:   repository,
+		categoryRepo: categoryRepo,
+		accountRepo:  accountRepo,
+		logger:       logger,
+	}
+}
+
+func (s *recurringOperationService) Create(ctx context.Context, dto dto.CreateRecurringOperationDTO) (string, error) {
+	if dto.Interval == 0 {
+		dto.Interval = 1
+	}
+
+	category, err := s.categoryRepo.FindByUUID(ctx, dto.CategoryUUID)
+	if err != nil {
+		return "", err
+	}
+	if err = checkOwner(ctx, category.UserUUID); err != nil {
+		return "", err
+	}
+	if dto.AccountUUID != "" {
+		account, err := s.accountRepo.FindByUUID(ctx, dto.AccountUUID)
+		if err != nil {
+			return "", err
+		}
+		if err = checkOwner(ctx, account.UserUUID); err != nil {
+			return "", err
+		}
+		if dto.Currency != "" && dto.Currency != account.Currency {
+			return "", apperror.ValidationError("operation currency must match account currency")
+		}
+	} else if dto.Currency == "" && category.Currency == "" {
+		return "", apperror.ValidationError("currency must be specified as category has no default currency")
+	}
+
+	recurring := entity.NewRecurringOperation(dto)
+	if recurring.NextDate == nil {
+		return "", apperror.ValidationError("schedule has no occurrences")
+	}
+
+	recurringUUID, err := s.repository.Create(ctx, *recurring)
+	if err != nil {
+		return "", fmt.Errorf("failed to create recurring operation: %w", err)
+	}
+	return recurringUUID, nil
+}
+
+func (s *recurringOperationService) GetByUUID(ctx context.Context, uuid string) (entity.RecurringOperation, error) {
+	recurring, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return recurring, fmt.Errorf("failed to find recurring operation by uuid: %w", err)
+	}
+	if err = checkOwner(ctx, recurring.UserUUID); err != nil {
+		return entity.RecurringOperation{}, err
+	}
+	return recurring, nil
+}
+
+func (s *recurringOperationService) GetByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.RecurringOperation], error) {
+	if err := checkOwner(ctx, uuid); err != nil {
+		return pagination.Page[entity.RecurringOperation]{}, err
+	}
+
+	recurringOperations, err := s.repository.FindByUserUUID(ctx, uuid, page)
+	if err != nil {
+		return pagination.Page[entity.RecurringOperation]{},
+			fmt.Errorf("failed to find recurring operations by user uuid: %w", err)
+	}
+	return pagination.NewPage(recurringOperations, page.Limit, entity.RecurringOperation.Cursor), nil
+}
+
+func (s *recurringOperationService) Pause(ctx context.Context, uuid string) error {
+	recurring, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, recurring.UserUUID); err != nil {
+		return err
+	}
+
+	recurring.Paused = true
+
+	err = s.repository.UpdateSchedule(ctx, recurring)
+	if err != nil {
+		return fmt.Errorf("failed to pause recurring operation: %w", err)
+	}
+	return nil
+}
+
+// Resume continues paused schedule from the first occurrence after now,
+// occurrences missed during the pause are skipped.
+func (s *recurringOperationService) Resume(ctx context.Context, uuid string) error {
+	recurring, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, recurring.UserUUID); err != nil {
+		return err
+	}
+	if !recurring.Paused {
+		return nil
+	}
+
+	recurring.Paused = false
+	recurring.Failures = 0
+	now := time.Now()
+	for recurring.NextDate != nil && recurring.NextDate.Before(now) {
+		recurring.Advance()
+	}
+
+	err = s.repository.UpdateSchedule(ctx, recurring)
+	if err != nil {
+		return fmt.Errorf("failed to resume recurring operation: %w", err)
+	}
+	return nil
+}
+
+func (s *recurringOperationService) Delete(ctx context.Context, uuid string) error {
+	recurring, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, recurring.UserUUID); err != nil {
+		return err
+	}
+
+	err = s.repository.Delete(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring operation by uuid: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/requestctx"
+	"time"
+)
+
+const (
+	// maxOccurrencesPerRun limits operations created for a recurring operation in one transaction,
+	// the rest of the due ones are created by the following runs
+	maxOccurrencesPerRun = 100
+	// maxFailures is the number of consecutive failed runs after which a recurring operation is paused
+	maxFailures = 5
+)
+
+// RecurringScheduler periodically creates operations of recurring operations which are due.
+type RecurringScheduler struct {
+	repository       RecurringOperationRepo
+	operationService controller.OperationService
+	transactor       Transactor
+	interval         time.Duration
+	logger           *logging.Logger
+}
+
+func NewRecurringScheduler(repository RecurringOperationRepo, operationService controller.OperationService,
+	transactor Transactor, interval time.Duration, logger *logging.Logger) *RecurringScheduler {
+	return &RecurringScheduler{
+		repository:       repository,
+		operationService: operationService,
+		transactor:       transactor,
+		interval:         interval,
+		logger:           logger,
+	}
+}
+
+// Run materializes due operations at start and then every interval until ctx is done.
+func (s *RecurringScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.materializeDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *RecurringScheduler) materializeDue(ctx context.Context, now time.Time) {
+	uuids, err := s.repository.FindDue(ctx, now)
+	if err != nil {
+		s.logger.Errorf("failed to find due recurring operations: %v", err)
+		return
+	}
+
+	for _, uuid := range uuids {
+		if err = s.materialize(ctx, uuid, now); err != nil {
+			s.logger.Errorf("failed to materialize recurring operation %s: %v", uuid, err)
+			s.recordFailure(ctx, uuid)
+		}
+	}
+}
+
+// materialize creates operations of the recurring operation due by now, at most maxOccurrencesPerRun
+// of them, and advances its schedule in one transaction. The recurring operation is locked and checked to be still due,
+// so an occurrence is created once even if several schedulers run at the same time.
+func (s *RecurringScheduler) materialize(ctx context.Context, uuid string, now time.Time) error {
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		recurring, err := s.repository.FindDueForUpdate(ctx, uuid, now)
+		if errors.Is(err, apperror.ErrNotFound) {
+			// paused, deleted or materialized in the meantime
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// operations are created on behalf of the owner of the recurring operation
+		ctx = requestctx.WithUserUUID(ctx, recurring.UserUUID)
+		for n := 0; n < maxOccurrencesPerRun && recurring.NextDate != nil && !recurring.NextDate.After(now); n++ {
+			if _, err = s.operationService.Create(ctx, occurrenceDTO(recurring)); err != nil {
+				return fmt.Errorf("failed to create operation of %s: %w", recurring.NextDate.Format(time.RFC3339), err)
+			}
+			recurring.Advance()
+		}
+		recurring.Failures = 0
+
+		return s.repository.UpdateSchedule(ctx, recurring)
+	})
+}
+
+// recordFailure counts the failed run of the recurring operation and pauses it after maxFailures
+// consecutive ones, so that a recurring operation which can not be materialized, e.g. as its account
+// is deleted, is not retried forever. The owner resumes it once the cause is fixed.
+func (s *RecurringScheduler) recordFailure(ctx context.Context, uuid string) {
+	recurring, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		s.logger.Errorf("failed to find recurring operation %s: %v", uuid, err)
+		return
+	}
+
+	recurring.Failures++
+	if recurring.Failures >= maxFailures {
+		recurring.Paused = true
+		s.logger.Warnf("recurring operation %s is paused after %d failures", uuid, recurring.Failures)
+	}
+
+	if err = s.repository.UpdateSchedule(ctx, recurring); err != nil {
+		s.logger.Errorf("failed to record failure of recurring operation %s: %v", uuid, err)
+	}
+}
+
+func occurrenceDTO(recurring entity.RecurringOperation) dto.CreateOperationDTO {
+	dateTime := *recurring.NextDate
+	return dto.CreateOperationDTO{
+		CategoryUUID: recurring.CategoryUUID,
+		AccountUUID:  recurring.AccountUUID,
+		MoneySum:     recurring.MoneySum,
+		Currency:     recurring.Currency,
+		Description:  recurring.Description,
+		DateTime:     &dateTime,
+	}
+}
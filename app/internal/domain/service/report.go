@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"sort"
+)
+
+type reportService struct {
+	operationRepo OperationRepo
+	categoryRepo  CategoryRepo
+	rateProvider  ExchangeRateProvider
+	logger        *logging.Logger
+}
+
+func NewReportService(operationRepo OperationRepo, categoryRepo CategoryRepo, rateProvider ExchangeRateProvider,
+	logger *logging.Logger) controller.ReportService {
+	return &reportService{
+		operationRepo: operationRepo,
+		categoryRepo:  categoryRepo,
+		rateProvider:  rateProvider,
+		logger:        logger,
+	}
+}
+
+func (s *reportService) GetByCategory(ctx context.Context,
+	dto dto.GetCategoryReportDTO) ([]entity.CategoryReport, error) {
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dto.UserUUID != "" && dto.UserUUID != userUUID {
+		return nil, apperror.ErrNotFound
+	}
+	dto.UserUUID = userUUID
+
+	if dto.Bucket == "" {
+		dto.Bucket = types.MonthBucket
+	}
+
+	filter := entity.NewReportFilter(dto)
+	filter.ByDay = dto.Currency != ""
+	rows, err := s.operationRepo.SumByCategory(ctx, *filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build report by category: %w", err)
+	}
+
+	var conv *converter
+	if dto.Currency != "" {
+		conv = newConverter(s.rateProvider, dto.Currency)
+	}
+
+	// rows are ordered by category and bucket, so buckets of a category are adjacent
+	reports := make([]entity.CategoryReport, 0)
+	for _, row := range rows {
+		if len(reports) == 0 || reports[len(reports)-1].CategoryUUID != row.CategoryUUID {
+			reports = append(reports, entity.CategoryReport{
+				CategoryUUID: row.CategoryUUID,
+				CategoryName: row.CategoryName,
+				CategoryType: row.CategoryType,
+				ParentUUID:   row.ParentUUID,
+				Buckets:      make([]entity.BucketSum, 0),
+			})
+		}
+		last := &reports[len(reports)-1]
+
+		if conv == nil {
+			last.Buckets = append(last.Buckets, row.BucketSum)
+			continue
+		}
+
+		income, err := conv.convert(ctx, row.Income, row.Currency, row.Day)
+		if err != nil {
+			return nil, err
+		}
+		expense, err := conv.convert(ctx, row.Expense, row.Currency, row.Day)
+		if err != nil {
+			return nil, err
+		}
+
+		if n := len(last.Buckets); n == 0 || !last.Buckets[n-1].Start.Equal(row.Start) {
+			last.Buckets = append(last.Buckets, entity.BucketSum{Currency: dto.Currency, Start: row.Start})
+		}
+		bucket := &last.Buckets[len(last.Buckets)-1]
+		bucket.Income = bucket.Income.Add(income)
+		bucket.Expense = bucket.Expense.Add(expense)
+	}
+
+	if dto.Rollup {
+		categories, err := s.categoryRepo.FindAllByUserUUID(ctx, dto.UserUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get categories by user uuid: %w", err)
+		}
+		return rollUpReports(reports, categories), nil
+	}
+	return reports, nil
+}
+
+// rollUpReports adds sums of subcategories to their parent categories. A category without
+// operations of its own is reported if its subcategories have some.
+func rollUpReports(reports []entity.CategoryReport, categories []entity.Category) []entity.CategoryReport {
+	own := make(map[string][]entity.BucketSum, len(reports))
+	for _, report := range reports {
+		own[report.CategoryUUID] = report.Buckets
+	}
+
+	children := make(map[string][]string)
+	for _, category := range categories {
+		if category.ParentUUID != "" {
+			children[category.ParentUUID] = append(children[category.ParentUUID], category.UUID)
+		}
+	}
+
+	totals := make(map[string][]entity.BucketSum, len(categories))
+	var total func(uuid string) []entity.BucketSum
+	total = func(uuid string) []entity.BucketSum {
+		if buckets, ok := totals[uuid]; ok {
+			return buckets
+		}
+		buckets := mergeBuckets(nil, own[uuid])
+		for _, child := range children[uuid] {
+			buckets = mergeBuckets(buckets, total(child))
+		}
+		totals[uuid] = buckets
+		return buckets
+	}
+
+	rolledUp := make([]entity.CategoryReport, 0, len(categories))
+	for _, category := range categories {
+		buckets := total(category.UUID)
+		if len(buckets) == 0 {
+			continue
+		}
+		rolledUp = append(rolledUp, entity.CategoryReport{
+			CategoryUUID: category.UUID,
+			CategoryName: category.Name,
+			CategoryType: category.Type,
+			ParentUUID:   category.ParentUUID,
+			Buckets:      buckets,
+		})
+	}
+	return rolledUp
+}
+
+// mergeBuckets adds buckets to merged summing ones of the same start and currency.
+// The result is ordered by start and currency.
+func mergeBuckets(merged, buckets []entity.BucketSum) []entity.BucketSum {
+	result := make([]entity.BucketSum, len(merged), len(merged)+len(buckets))
+	copy(result, merged)
+
+	for _, bucket := range buckets {
+		i := 0
+		for ; i < len(result); i++ {
+			if result[i].Start.Equal(bucket.Start) && result[i].Currency == bucket.Currency {
+				break
+			}
+		}
+		if i == len(result) {
+			result = append(result, bucket)
+			continue
+		}
+		result[i].Income = result[i].Income.Add(bucket.Income)
+		result[i].Expense = result[i].Expense.Add(bucket.Expense)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].Start.Equal(result[j].Start) {
+			return result[i].Start.Before(result[j].Start)
+		}
+		return result[i].Currency < result[j].Currency
+	})
+	return result
+}
@@ -0,0 +1,9 @@
+package service
+
+import "context"
+
+// Transactor runs fn in a transaction: every repository call made with the context passed
+// to fn either takes effect together with the others or not at all.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"time"
+)
+
+type TransferRepo interface {
+	Create(ctx context.Context, transfer entity.Transfer) (string, error)
+	FindByUUID(ctx context.Context, uuid string) (entity.Transfer, error)
+	Update(ctx context.Context, transfer entity.Transfer) error
+	Delete(ctx context.Context, uuid string) ([]entity.Operation, error)
+	FindDeletedByUUID(ctx context.Context, uuid string) (entity.Transfer, error)
+	Restore(ctx context.Context, uuid string) ([]entity.Operation, error)
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+type transferService struct {
+	transferRepo  TransferRepo
+	operationRepo OperationRepo
+	accountRepo   AccountRepo
+	outboxRepo    OutboxRepo
+	auditRepo     AuditRepo
+	transactor    Transactor
+	logger        *logging.Logger
+}
+
+func NewTransferService(transferRepo TransferRepo, operationRepo OperationRepo, accountRepo AccountRepo,
+	outboxRepo OutboxRepo, auditRepo AuditRepo, transactor Transactor, logger *logging.Logger) controller.TransferService {
+	return &transferService{
+		transferRepo:  transferRepo,
+		operationRepo: operationRepo,
+		accountRepo:   accountRepo,
+		outboxRepo:    outboxRepo,
+		auditRepo:     auditRepo,
+		transactor:    transactor,
+		logger:        logger,
+	}
+}
+
+func (s *transferService) Create(ctx context.Context, dto dto.CreateTransferDTO) (string, error) {
+	transfer := entity.NewTransfer(dto)
+	if err := s.resolveAccounts(ctx, transfer); err != nil {
+		return "", err
+	}
+
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		transferUUID, err := s.transferRepo.Create(ctx, *transfer)
+		if err != nil {
+			return err
+		}
+		transfer.UUID = transferUUID
+
+		// legs are operations, so they are audited and published as operations created directly
+		for _, leg := range []entity.Operation{transfer.Debit(), transfer.Credit()} {
+			if leg.UUID, err = s.operationRepo.Create(ctx, leg); err != nil {
+				return err
+			}
+			err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, leg.UUID, entity.CreateAuditAction,
+				nil, leg)
+			if err != nil {
+				return err
+			}
+			if err = saveEvent(ctx, s.outboxRepo, entity.OperationCreatedEvent, leg.UUID, leg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create transfer: %w", err)
+	}
+	return transfer.UUID, nil
+}
+
+func (s *transferService) GetByUUID(ctx context.Context, uuid string) (entity.Transfer, error) {
+	transfer, err := s.transferRepo.FindByUUID(ctx, uuid)
+	if err != nil {
+		return transfer, fmt.Errorf("failed to find transfer by uuid: %w", err)
+	}
+	if err = checkOwner(ctx, transfer.UserUUID); err != nil {
+		return entity.Transfer{}, err
+	}
+	return transfer, nil
+}
+
+func (s *transferService) Update(ctx context.Context, dto dto.UpdateTransferDTO) error {
+	transfer, err := s.transferRepo.FindByUUID(ctx, dto.UUID)
+	if err != nil {
+		return fmt.Errorf("failed to find transfer by uuid: %w", err)
+	}
+	if err = checkOwner(ctx, transfer.UserUUID); err != nil {
+		return err
+	}
+
+	updTransfer := entity.UpdatedTransfer(transfer, dto)
+	if err = s.resolveAccounts(ctx, updTransfer); err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.transferRepo.Update(ctx, *updTransfer); err != nil {
+			return err
+		}
+
+		operations, err := s.operationRepo.FindByTransferUUID(ctx, updTransfer.UUID)
+		if err != nil {
+			return err
+		}
+		for _, operation := range operations {
+			leg := updTransfer.Credit()
+			if operation.MoneySum.IsNegative() {
+				leg = updTransfer.Debit()
+			}
+			leg.UUID = operation.UUID
+			operation.UserUUID = transfer.UserUUID
+
+			if err = s.operationRepo.Update(ctx, leg); err != nil {
+				return err
+			}
+			err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, leg.UUID, entity.UpdateAuditAction,
+				operation, leg)
+			if err != nil {
+				return err
+			}
+			if err = saveEvent(ctx, s.outboxRepo, entity.OperationUpdatedEvent, leg.UUID, leg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update transfer: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes the transfer together with its operations. Deleted transfer can be restored
+// until it is purged after the retention period.
+func (s *transferService) Delete(ctx context.Context, uuid string) error {
+	transfer, err := s.transferRepo.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, transfer.UserUUID); err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// legs are deleted with the transfer
+		operations, err := s.transferRepo.Delete(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		for _, operation := range operations {
+			operation.UserUUID = transfer.UserUUID
+			err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID, entity.DeleteAuditAction,
+				operation, nil)
+			if err != nil {
+				return err
+			}
+			err = saveEvent(ctx, s.outboxRepo, entity.OperationDeletedEvent, operation.UUID, operation)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete transfer by uuid: %w", err)
+	}
+	return nil
+}
+
+// Restore restores deleted transfer together with its operations.
+func (s *transferService) Restore(ctx context.Context, uuid string) error {
+	transfer, err := s.transferRepo.FindDeletedByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, transfer.UserUUID); err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		operations, err := s.transferRepo.Restore(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		for _, operation := range operations {
+			operation.UserUUID = transfer.UserUUID
+			err = saveAudit(ctx, s.auditRepo, entity.OperationAuditEntity, operation.UUID, entity.RestoreAuditAction,
+				nil, operation)
+			if err != nil {
+				return err
+			}
+			err = saveEvent(ctx, s.outboxRepo, entity.OperationRestoredEvent, operation.UUID, operation)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore transfer by uuid: %w", err)
+	}
+	return nil
+}
+
+// resolveAccounts checks that the transfer is made between two different accounts of
+// the current user in the same currency and sets transfer's user and currency from them.
+func (s *transferService) resolveAccounts(ctx context.Context, transfer *entity.Transfer) error {
+	if transfer.FromAccountUUID == transfer.ToAccountUUID {
+		return apperror.ValidationError("transfer must be made between different accounts")
+	}
+
+	from, err := s.accountRepo.FindByUUID(ctx, transfer.FromAccountUUID)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, from.UserUUID); err != nil {
+		return err
+	}
+	to, err := s.accountRepo.FindByUUID(ctx, transfer.ToAccountUUID)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, to.UserUUID); err != nil {
+		return err
+	}
+
+	if from.Currency != to.Currency {
+		return apperror.ValidationError("accounts must have the same currency")
+	}
+
+	transfer.UserUUID = from.UserUUID
+	transfer.Currency = from.Currency
+	return nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"testing"
+	"time"
+)
+
+// fakeTransferRepo keeps transfers in memory. Operations of every transfer are the same
+// operations, which are returned by the methods deleting or restoring them.
+type fakeTransferRepo struct {
+	TransferRepo
+	transfers  map[string]entity.Transfer
+	deleted    map[string]bool
+	operations []entity.Operation
+}
+
+func newFakeTransferRepo(operations []entity.Operation, transfers ...entity.Transfer) *fakeTransferRepo {
+	r := &fakeTransferRepo{
+		transfers:  make(map[string]entity.Transfer),
+		deleted:    make(map[string]bool),
+		operations: operations,
+	}
+	for _, transfer := range transfers {
+		r.transfers[transfer.UUID] = transfer
+	}
+	return r
+}
+
+func (r *fakeTransferRepo) find(uuid string, deleted bool) (entity.Transfer, error) {
+	transfer, ok := r.transfers[uuid]
+	if !ok || r.deleted[uuid] != deleted {
+		return entity.Transfer{}, apperror.ErrNotFound
+	}
+	return transfer, nil
+}
+
+func (r *fakeTransferRepo) FindByUUID(_ context.Context, uuid string) (entity.Transfer, error) {
+	return r.find(uuid, false)
+}
+
+func (r *fakeTransferRepo) FindDeletedByUUID(_ context.Context, uuid string) (entity.Transfer, error) {
+	return r.find(uuid, true)
+}
+
+func (r *fakeTransferRepo) Delete(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	if !inTransaction(ctx) {
+		return nil, errOutsideTransaction
+	}
+	r.deleted[uuid] = true
+	return r.operations, nil
+}
+
+func (r *fakeTransferRepo) Restore(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	if !inTransaction(ctx) {
+		return nil, errOutsideTransaction
+	}
+	r.deleted[uuid] = false
+	return r.operations, nil
+}
+
+func (r *fakeTransferRepo) Purge(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestTransferServiceDeleteAndRestore(t *testing.T) {
+	transfer := entity.Transfer{UUID: "t1", UserUUID: "u1"}
+	legs := []entity.Operation{{UUID: "o1", TransferUUID: "t1"}, {UUID: "o2", TransferUUID: "t1"}}
+
+	tests := []struct {
+		name        string
+		user        string
+		deleted     bool
+		restore     bool
+		wantStatus  int
+		wantDeleted bool
+		wantAudit   []string
+		wantEvents  []string
+	}{
+		{name: "delete keeps the transfer for restore", user: "u1", wantDeleted: true,
+			wantAudit:  []string{"operation o1 delete", "operation o2 delete"},
+			wantEvents: []string{"operation.deleted o1", "operation.deleted o2"}},
+		{name: "delete transfer of another user", user: "u2", wantStatus: http.StatusNotFound},
+		{name: "delete deleted transfer", user: "u1", deleted: true, wantStatus: http.StatusNotFound,
+			wantDeleted: true},
+		{name: "restore", user: "u1", deleted: true, restore: true,
+			wantAudit:  []string{"operation o1 restore", "operation o2 restore"},
+			wantEvents: []string{"operation.restored o1", "operation.restored o2"}},
+		{name: "restore transfer of another user", user: "u2", deleted: true, restore: true,
+			wantStatus: http.StatusNotFound, wantDeleted: true},
+		{name: "restore transfer which is not deleted", user: "u1", restore: true, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeTransferRepo(legs, transfer)
+			repo.deleted["t1"] = tt.deleted
+			outboxRepo, auditRepo := &fakeOutboxRepo{}, &fakeAuditRepo{}
+			s := NewTransferService(repo, nil, nil, outboxRepo, auditRepo, fakeTransactor{}, newTestLogger())
+
+			var err error
+			if tt.restore {
+				err = s.Restore(userContext(tt.user), "t1")
+			} else {
+				err = s.Delete(userContext(tt.user), "t1")
+			}
+			if status := errorStatus(err); status != tt.wantStatus {
+				t.Fatalf("error = %v, want status %d", err, tt.wantStatus)
+			}
+			if repo.deleted["t1"] != tt.wantDeleted {
+				t.Errorf("transfer deleted = %t, want %t", repo.deleted["t1"], tt.wantDeleted)
+			}
+			if got := auditRepo.records(); !equalStrings(got, tt.wantAudit) {
+				t.Errorf("audit records = %v, want %v", got, tt.wantAudit)
+			}
+			if got := outboxRepo.events(); !equalStrings(got, tt.wantEvents) {
+				t.Errorf("outbox events = %v, want %v", got, tt.wantEvents)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/controller/dto"
+	controller "operation-service/internal/controller/http"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/webhook"
+	"time"
+)
+
+type WebhookRepo interface {
+	Create(ctx context.Context, webhook entity.Webhook) (string, error)
+	FindByUUID(ctx context.Context, uuid string) (entity.Webhook, error)
+	FindByUserUUID(ctx context.Context, uuid string, page pagination.Params) ([]entity.Webhook, error)
+	Delete(ctx context.Context, uuid string) error
+	CreateDeliveries(ctx context.Context, userUUID, event string, payload []byte) error
+	FindDeliveries(ctx context.Context, webhookUUID string, page pagination.Params) ([]entity.WebhookDelivery, error)
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]string, error)
+	FindDueDeliveryForUpdate(ctx context.Context, uuid string, now time.Time) (entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+}
+
+type webhookService struct {
+	repository WebhookRepo
+	sender     *webhook.Sender
+	logger     *logging.Logger
+}
+
+func NewWebhookService(repository WebhookRepo, sender *webhook.Sender,
+	logger *logging.Logger) controller.WebhookService {
+	return &webhookService{
+		repository: repository,
+		sender:     sender,
+		logger:     logger,
+	}
+}
+
+func (s *webhookService) Create(ctx context.Context, dto dto.CreateWebhookDTO) (string, error) {
+	userUUID, err := currentUser(ctx)
+	if err != nil {
+		return "", err
+	}
+	if dto.UserUUID == "" {
+		dto.UserUUID = userUUID
+	}
+	if dto.UserUUID != userUUID {
+		return "", apperror.ErrNotFound
+	}
+
+	if err = s.sender.CheckURL(ctx, dto.URL); err != nil {
+		s.logger.Debugf("webhook url %s is rejected: %v", dto.URL, err)
+		return "", apperror.FieldValidationError("url", apperror.CodeInvalid,
+			"url must resolve to public addresses")
+	}
+
+	webhook := entity.NewWebhook(dto)
+	webhookUUID, err := s.repository.Create(ctx, *webhook)
+	if err != nil {
+		return "", fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return webhookUUID, nil
+}
+
+func (s *webhookService) GetByUUID(ctx context.Context, uuid string) (entity.Webhook, error) {
+	webhook, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return webhook, fmt.Errorf("failed to find webhook by uuid: %w", err)
+	}
+	if err = checkOwner(ctx, webhook.UserUUID); err != nil {
+		return entity.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (s *webhookService) GetByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.Webhook], error) {
+	if err := checkOwner(ctx, uuid); err != nil {
+		return pagination.Page[entity.Webhook]{}, err
+	}
+
+	webhooks, err := s.repository.FindByUserUUID(ctx, uuid, page)
+	if err != nil {
+		return pagination.Page[entity.Webhook]{}, fmt.Errorf("failed to find webhooks by user uuid: %w", err)
+	}
+	return pagination.NewPage(webhooks, page.Limit, entity.Webhook.Cursor), nil
+}
+
+func (s *webhookService) GetDeliveries(ctx context.Context, uuid string,
+	page pagination.Params) (pagination.Page[entity.WebhookDelivery], error) {
+	webhook, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return pagination.Page[entity.WebhookDelivery]{}, err
+	}
+	if err = checkOwner(ctx, webhook.UserUUID); err != nil {
+		return pagination.Page[entity.WebhookDelivery]{}, err
+	}
+
+	deliveries, err := s.repository.FindDeliveries(ctx, uuid, page)
+	if err != nil {
+		return pagination.Page[entity.WebhookDelivery]{}, fmt.Errorf("failed to find webhook deliveries: %w", err)
+	}
+	return pagination.NewPage(deliveries, page.Limit, entity.WebhookDelivery.Cursor), nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, uuid string) error {
+	webhook, err := s.repository.FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err = checkOwner(ctx, webhook.UserUUID); err != nil {
+		return err
+	}
+
+	err = s.repository.Delete(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook by uuid: %w", err)
+	}
+	return nil
+}
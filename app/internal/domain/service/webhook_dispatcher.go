@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/webhook"
+	"time"
+)
+
+const (
+	dispatchBatchSize = 100
+	firstRetryDelay   = 30 * time.Second
+	maxRetryDelay     = 6 * time.Hour
+)
+
+// WebhookDispatcher periodically sends pending webhook deliveries. Failed deliveries are retried
+// with exponential backoff until they are delivered or maxAttempts is reached.
+type WebhookDispatcher struct {
+	repository  WebhookRepo
+	sender      *webhook.Sender
+	transactor  Transactor
+	interval    time.Duration
+	maxAttempts int
+	logger      *logging.Logger
+}
+
+func NewWebhookDispatcher(repository WebhookRepo, sender *webhook.Sender, transactor Transactor,
+	interval time.Duration, maxAttempts int, logger *logging.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repository:  repository,
+		sender:      sender,
+		transactor:  transactor,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+// Run sends due deliveries at start and then every interval until ctx is done.
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatchDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatchDue(ctx context.Context, now time.Time) {
+	uuids, err := d.repository.FindDueDeliveries(ctx, now, dispatchBatchSize)
+	if err != nil {
+		d.logger.Errorf("failed to find due webhook deliveries: %v", err)
+		return
+	}
+
+	for _, uuid := range uuids {
+		if err = d.dispatch(ctx, uuid, now); err != nil {
+			d.logger.Errorf("failed to dispatch webhook delivery %s: %v", uuid, err)
+		}
+	}
+}
+
+// dispatch makes an attempt to send the delivery and saves its result. The delivery is locked
+// while it is sent, so it is not sent twice by dispatchers running at the same time.
+func (d *WebhookDispatcher) dispatch(ctx context.Context, uuid string, now time.Time) error {
+	return d.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		delivery, err := d.repository.FindDueDeliveryForUpdate(ctx, uuid, now)
+		if errors.Is(err, apperror.ErrNotFound) {
+			// sent by another dispatcher or webhook is deleted
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		status, sendErr := d.sender.Send(ctx, delivery.URL, delivery.Secret, delivery.Event, delivery.UUID,
+			delivery.Payload)
+
+		delivery.Attempts++
+		delivery.ResponseStatus = nil
+		if status != 0 {
+			delivery.ResponseStatus = &status
+		}
+
+		sentAt := time.Now()
+		switch {
+		case sendErr == nil:
+			delivery.Status = types.DeliveredDelivery
+			delivery.DeliveredAt = &sentAt
+			delivery.NextAttemptAt = nil
+			delivery.LastError = ""
+		case delivery.Attempts >= d.maxAttempts:
+			delivery.Status = types.FailedDelivery
+			delivery.NextAttemptAt = nil
+			delivery.LastError = sendErr.Error()
+		default:
+			nextAttemptAt := sentAt.Add(retryDelay(delivery.Attempts))
+			delivery.NextAttemptAt = &nextAttemptAt
+			delivery.LastError = sendErr.Error()
+		}
+
+		return d.repository.UpdateDelivery(ctx, delivery)
+	})
+}
+
+// retryDelay returns the delay before the attempt following the given number of failed ones.
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
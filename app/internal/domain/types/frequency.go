@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// Frequency is a unit of recurrence of a scheduled operation.
+type Frequency string
+
+const (
+	Daily   Frequency = "daily"
+	Weekly  Frequency = "weekly"
+	Monthly Frequency = "monthly"
+	Yearly  Frequency = "yearly"
+)
+
+// Add returns t moved forward by n periods. Monthly and yearly periods keep the day of month
+// of t and fall back to the last day of shorter months, so Jan 31 plus a month is Feb 28 (29).
+func (f Frequency) Add(t time.Time, n int) time.Time {
+	switch f {
+	case Daily:
+		return t.AddDate(0, 0, n)
+	case Weekly:
+		return t.AddDate(0, 0, 7*n)
+	case Monthly:
+		return addMonths(t, n)
+	case Yearly:
+		return addMonths(t, 12*n)
+	}
+	return t
+}
+
+func addMonths(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+
+	// day 0 of the month after the target one is the last day of the target month
+	lastDay := time.Date(year, month+time.Month(n)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month+time.Month(n), day, hour, minute, sec, t.Nanosecond(), t.Location())
+}
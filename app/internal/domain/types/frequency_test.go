@@ -0,0 +1,52 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFrequencyAdd(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		frequency Frequency
+		start     time.Time
+		n         int
+		want      time.Time
+	}{
+		{name: "daily", frequency: Daily, start: date(2024, time.February, 28), n: 2, want: date(2024, time.March, 1)},
+		{name: "weekly", frequency: Weekly, start: date(2024, time.December, 30), n: 1, want: date(2025, time.January, 6)},
+		{name: "monthly", frequency: Monthly, start: date(2024, time.January, 15), n: 1,
+			want: date(2024, time.February, 15)},
+		{name: "monthly from month end to february", frequency: Monthly, start: date(2023, time.January, 31), n: 1,
+			want: date(2023, time.February, 28)},
+		{name: "monthly from month end to february of leap year", frequency: Monthly,
+			start: date(2024, time.January, 31), n: 1, want: date(2024, time.February, 29)},
+		{name: "monthly keeps day of start after short month", frequency: Monthly,
+			start: date(2024, time.January, 31), n: 2, want: date(2024, time.March, 31)},
+		{name: "monthly to shorter month", frequency: Monthly, start: date(2024, time.March, 31), n: 1,
+			want: date(2024, time.April, 30)},
+		{name: "monthly across year", frequency: Monthly, start: date(2024, time.December, 31), n: 2,
+			want: date(2025, time.February, 28)},
+		{name: "yearly from leap day", frequency: Yearly, start: date(2024, time.February, 29), n: 1,
+			want: date(2025, time.February, 28)},
+		{name: "yearly from leap day to leap year", frequency: Yearly, start: date(2024, time.February, 29), n: 4,
+			want: date(2028, time.February, 29)},
+		{name: "zero periods", frequency: Monthly, start: date(2024, time.January, 31), n: 0,
+			want: date(2024, time.January, 31)},
+		{name: "unknown frequency", frequency: "hourly", start: date(2024, time.January, 31), n: 3,
+			want: date(2024, time.January, 31)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.frequency.Add(tt.start, tt.n); !got.Equal(tt.want) {
+				t.Errorf("%s.Add(%s, %d) = %s, want %s", tt.frequency, tt.start.Format(time.RFC3339), tt.n,
+					got.Format(time.RFC3339), tt.want.Format(time.RFC3339))
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const minorUnitsPerMajor = 100
+
+var (
+	ErrInvalidMoney        = errors.New("money must be a decimal number with at most two fractional digits")
+	ErrMoneyOutOfRange     = errors.New("money is out of range")
+	errUnsupportedMoneySQL = errors.New("unsupported money type in database")
+)
+
+// Money is an exact amount of money kept as an integer number of minor units (cents).
+// It is encoded to JSON as a decimal string, e.g. "-12.30", and to SQL as NUMERIC.
+// Money is a struct rather than an integer type, so it can not be passed to the
+// database driver or used in arithmetic as a bare number of cents by mistake.
+type Money struct {
+	minor int64
+}
+
+func MoneyFromMinor(minor int64) Money {
+	return Money{minor: minor}
+}
+
+// ParseMoney parses decimal string with optional sign and at most two fractional digits.
+func ParseMoney(s string) (Money, error) {
+	negative := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		negative = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	integer, fraction, hasPoint := strings.Cut(s, ".")
+	if integer == "" || (hasPoint && fraction == "") || len(fraction) > 2 ||
+		!isDigits(integer) || !isDigits(fraction) {
+		return Money{}, ErrInvalidMoney
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	major, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil || major > (math.MaxInt64-99)/minorUnitsPerMajor {
+		return Money{}, ErrMoneyOutOfRange
+	}
+	minor, _ := strconv.ParseInt(fraction, 10, 64)
+
+	m := Money{minor: major*minorUnitsPerMajor + minor}
+	if negative {
+		m = m.Neg()
+	}
+	return m, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.minor > 0
+}
+
+func (m Money) Neg() Money {
+	return Money{minor: -m.minor}
+}
+
+func (m Money) Abs() Money {
+	if m.minor < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{minor: m.minor + other.minor}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{minor: m.minor - other.minor}
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or greater than other.
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.minor < other.minor:
+		return -1
+	case m.minor > other.minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Convert returns m exchanged at rate, rounded half away from zero to minor units.
+func (m Money) Convert(rate Rate) Money {
+	if rate.rat == nil {
+		return Money{}
+	}
+
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(m.minor), rate.rat)
+	quotient, remainder := new(big.Int).QuoRem(product.Num(), product.Denom(), new(big.Int))
+
+	// |remainder| * 2 >= denominator means the fractional part is at least one half
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(product.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+	return Money{minor: quotient.Int64()}
+}
+
+func (m Money) String() string {
+	sign := ""
+	minor := m.minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorUnitsPerMajor, minor%minorUnitsPerMajor)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts both a decimal string and a JSON number. Numbers are parsed
+// from their literal representation, so no precision is lost to float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return ErrInvalidMoney
+		}
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan reads NUMERIC column. The driver may pass it in scientific notation
+// (e.g. "1230e-2"), so the text is parsed as an arbitrary-precision rational.
+func (m *Money) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	case int64:
+		*m = Money{minor: src * minorUnitsPerMajor}
+		return nil
+	default:
+		return errUnsupportedMoneySQL
+	}
+
+	r, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return ErrInvalidMoney
+	}
+	r.Mul(r, big.NewRat(minorUnitsPerMajor, 1))
+	if !r.IsInt() {
+		return ErrInvalidMoney
+	}
+	if !r.Num().IsInt64() {
+		return ErrMoneyOutOfRange
+	}
+
+	*m = Money{minor: r.Num().Int64()}
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,128 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int64
+		wantErr error
+	}{
+		{name: "two fractional digits", input: "12.30", want: 1230},
+		{name: "one fractional digit", input: "-0.5", want: -50},
+		{name: "integer with plus sign", input: "+7", want: 700},
+		{name: "zero", input: "0", want: 0},
+		{name: "greatest amount", input: "92233720368547757.99", want: 9223372036854775799},
+		{name: "empty", input: "", wantErr: ErrInvalidMoney},
+		{name: "point without fraction", input: "1.", wantErr: ErrInvalidMoney},
+		{name: "fraction without integer", input: ".5", wantErr: ErrInvalidMoney},
+		{name: "three fractional digits", input: "1.234", wantErr: ErrInvalidMoney},
+		{name: "exponent", input: "1e3", wantErr: ErrInvalidMoney},
+		{name: "double sign", input: "--1", wantErr: ErrInvalidMoney},
+		{name: "spaces", input: " 1", wantErr: ErrInvalidMoney},
+		{name: "too large", input: "92233720368547758", wantErr: ErrMoneyOutOfRange},
+		{name: "overflows int64", input: "99999999999999999999", wantErr: ErrMoneyOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseMoney(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got.Minor() != tt.want {
+				t.Errorf("ParseMoney(%q) = %d minor units, want %d", tt.input, got.Minor(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		minor int64
+		want  string
+	}{
+		{minor: 1230, want: "12.30"},
+		{minor: -1230, want: "-12.30"},
+		{minor: 5, want: "0.05"},
+		{minor: -5, want: "-0.05"},
+		{minor: 0, want: "0.00"},
+	}
+
+	for _, tt := range tests {
+		if got := MoneyFromMinor(tt.minor).String(); got != tt.want {
+			t.Errorf("MoneyFromMinor(%d).String() = %q, want %q", tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    int64
+		wantErr error
+	}{
+		{name: "numeric text", src: "12.3", want: 1230},
+		{name: "negative numeric text", src: "-0.01", want: -1},
+		{name: "scientific notation", src: []byte("1230e-2"), want: 1230},
+		{name: "integer", src: int64(5), want: 500},
+		{name: "fraction of minor unit", src: "1.234", wantErr: ErrInvalidMoney},
+		{name: "not a number", src: "abc", wantErr: ErrInvalidMoney},
+		{name: "out of range", src: "1e30", wantErr: ErrMoneyOutOfRange},
+		{name: "unsupported type", src: 1.5, wantErr: errUnsupportedMoneySQL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := m.Scan(tt.src)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Scan(%v) error = %v, want %v", tt.src, err, tt.wantErr)
+			}
+			if err == nil && m.Minor() != tt.want {
+				t.Errorf("Scan(%v) = %d minor units, want %d", tt.src, m.Minor(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyConvert(t *testing.T) {
+	tests := []struct {
+		name  string
+		minor int64
+		rate  string
+		want  int64
+	}{
+		{name: "exact", minor: 1000, rate: "1.5", want: 1500},
+		{name: "half rounds up", minor: 100, rate: "1.005", want: 101},
+		{name: "negative half rounds down", minor: -100, rate: "1.005", want: -101},
+		{name: "less than half rounds down", minor: 1, rate: "0.4", want: 0},
+		{name: "half of minor unit", minor: 1, rate: "0.5", want: 1},
+		{name: "negative half of minor unit", minor: -1, rate: "0.5", want: -1},
+		{name: "more than half rounds up", minor: 334, rate: "0.0015", want: 1},
+		{name: "small rate", minor: 100000, rate: "0.0000100001", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatalf("ParseRate(%q) error = %v", tt.rate, err)
+			}
+			if got := MoneyFromMinor(tt.minor).Convert(rate); got.Minor() != tt.want {
+				t.Errorf("%d converted at %s = %d, want %d", tt.minor, tt.rate, got.Minor(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyConvertZeroRate(t *testing.T) {
+	if got := MoneyFromMinor(1000).Convert(Rate{}); !got.IsZero() {
+		t.Errorf("converted at zero rate = %s, want 0.00", got)
+	}
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// Rates are kept in NUMERIC(20, 10) columns: 10 integer and 10 fractional digits.
+const (
+	rateScale     = 10
+	rateIntDigits = 10
+)
+
+var ErrInvalidRate = errors.New("rate must be a positive decimal number with at most 10 integer " +
+	"and 10 fractional digits")
+
+// Rate is an exchange rate: amount of quote currency for one unit of base currency.
+// Like Money, it is kept exact and encoded to JSON as a decimal string.
+type Rate struct {
+	rat *big.Rat
+}
+
+func OneRate() Rate {
+	return Rate{rat: big.NewRat(1, 1)}
+}
+
+func ParseRate(s string) (Rate, error) {
+	if strings.ContainsAny(s, "eE/") {
+		return Rate{}, ErrInvalidRate
+	}
+	integer, fraction, _ := strings.Cut(s, ".")
+	if len(fraction) > rateScale {
+		return Rate{}, ErrInvalidRate
+	}
+	if len(strings.TrimLeft(strings.TrimPrefix(integer, "+"), "0")) > rateIntDigits {
+		return Rate{}, ErrInvalidRate
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || r.Sign() <= 0 {
+		return Rate{}, ErrInvalidRate
+	}
+	return Rate{rat: r}, nil
+}
+
+func (r Rate) IsZero() bool {
+	return r.rat == nil || r.rat.Sign() == 0
+}
+
+func (r Rate) String() string {
+	if r.rat == nil {
+		return "0"
+	}
+	s := r.rat.FloatString(rateScale)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return ErrInvalidRate
+		}
+	}
+
+	parsed, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Scan reads NUMERIC column, see Money.Scan.
+func (r *Rate) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return ErrInvalidRate
+	}
+
+	rat, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return ErrInvalidRate
+	}
+	*r = Rate{rat: rat}
+	return nil
+}
+
+func (r Rate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "decimal", input: "1.25", want: "1.25"},
+		{name: "integer", input: "2", want: "2"},
+		{name: "plus sign", input: "+2", want: "2"},
+		{name: "trailing zeros", input: "1.5000", want: "1.5"},
+		{name: "ten fractional digits", input: "0.0000000001", want: "0.0000000001"},
+		{name: "ten integer digits", input: "9999999999.5", want: "9999999999.5"},
+		{name: "leading zeros", input: "00000000001.5", want: "1.5"},
+		{name: "eleven fractional digits", input: "0.00000000001", wantErr: ErrInvalidRate},
+		{name: "eleven integer digits", input: "10000000000", wantErr: ErrInvalidRate},
+		{name: "zero", input: "0", wantErr: ErrInvalidRate},
+		{name: "negative", input: "-1", wantErr: ErrInvalidRate},
+		{name: "exponent", input: "1e2", wantErr: ErrInvalidRate},
+		{name: "fraction", input: "1/2", wantErr: ErrInvalidRate},
+		{name: "empty", input: "", wantErr: ErrInvalidRate},
+		{name: "not a number", input: "abc", wantErr: ErrInvalidRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRate(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseRate(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ParseRate(%q) = %s, want %s", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "string", input: `"1.5"`, want: "1.5"},
+		{name: "number", input: `1.5`, want: "1.5"},
+		{name: "null", input: `null`, want: "0"},
+		{name: "invalid", input: `"-1"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Rate
+			err := r.UnmarshalJSON([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON(%s) error = %v, want error %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && r.String() != tt.want {
+				t.Errorf("UnmarshalJSON(%s) = %s, want %s", tt.input, r, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "numeric text", src: "1.5000000000", want: "1.5"},
+		{name: "bytes", src: []byte("0.0000000001"), want: "0.0000000001"},
+		{name: "scientific notation", src: "15e-1", want: "1.5"},
+		{name: "not a number", src: "abc", wantErr: true},
+		{name: "unsupported type", src: 1.5, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r Rate
+			err := r.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v, want error %v", tt.src, err, tt.wantErr)
+			}
+			if err == nil && r.String() != tt.want {
+				t.Errorf("Scan(%v) = %s, want %s", tt.src, r, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,73 @@
 package types
 
+import "time"
+
 type CategoryType string
 
 const (
 	IncomeType  CategoryType = "Income"
 	ExpenseType CategoryType = "Expense"
 )
+
+type ReportBucket string
+
+const (
+	DayBucket   ReportBucket = "day"
+	WeekBucket  ReportBucket = "week"
+	MonthBucket ReportBucket = "month"
+)
+
+type BudgetPeriod string
+
+const (
+	WeekPeriod  BudgetPeriod = "week"
+	MonthPeriod BudgetPeriod = "month"
+	YearPeriod  BudgetPeriod = "year"
+)
+
+// Start returns the beginning of the period containing t. Weeks start on Monday.
+func (p BudgetPeriod) Start(t time.Time) time.Time {
+	year, month, day := t.Date()
+	switch p {
+	case WeekPeriod:
+		// Sunday is the last day of a week
+		weekday := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-weekday, 0, 0, 0, 0, t.Location())
+	case YearPeriod:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// Next returns the beginning of the period following the one starting at start.
+func (p BudgetPeriod) Next(start time.Time) time.Time {
+	switch p {
+	case WeekPeriod:
+		return start.AddDate(0, 0, 7)
+	case YearPeriod:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// Previous returns the beginning of the period preceding the one starting at start.
+func (p BudgetPeriod) Previous(start time.Time) time.Time {
+	switch p {
+	case WeekPeriod:
+		return start.AddDate(0, 0, -7)
+	case YearPeriod:
+		return start.AddDate(-1, 0, 0)
+	default:
+		return start.AddDate(0, -1, 0)
+	}
+}
+
+type DeliveryStatus string
+
+const (
+	PendingDelivery   DeliveryStatus = "pending"
+	DeliveredDelivery DeliveryStatus = "delivered"
+	FailedDelivery    DeliveryStatus = "failed"
+)
@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"context"
+	"operation-service/internal/domain/entity"
+	"sync"
+)
+
+// MemoryPublisher keeps the latest published events in memory, older ones are dropped once capacity
+// is reached. It is meant for local runs and tests, where there are no other services to consume
+// the events.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []entity.OutboxEvent
+	// next is the index in events to write the next event to once events is full
+	next int
+}
+
+func NewMemoryPublisher(capacity int) *MemoryPublisher {
+	return &MemoryPublisher{events: make([]entity.OutboxEvent, 0, capacity)}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event entity.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case cap(p.events) == 0:
+	case len(p.events) < cap(p.events):
+		p.events = append(p.events, event)
+	default:
+		p.events[p.next] = event
+		p.next = (p.next + 1) % len(p.events)
+	}
+	return nil
+}
+
+// Events returns the kept events in the order of publishing.
+func (p *MemoryPublisher) Events() []entity.OutboxEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]entity.OutboxEvent, 0, len(p.events))
+	events = append(events, p.events[p.next:]...)
+	return append(events, p.events[:p.next]...)
+}
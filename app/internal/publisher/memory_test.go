@@ -0,0 +1,44 @@
+package publisher
+
+import (
+	"context"
+	"operation-service/internal/domain/entity"
+	"testing"
+)
+
+func TestMemoryPublisher(t *testing.T) {
+	tests := []struct {
+		name      string
+		capacity  int
+		published []string
+		want      []string
+	}{
+		{name: "below capacity", capacity: 3, published: []string{"1", "2"}, want: []string{"1", "2"}},
+		{name: "at capacity", capacity: 3, published: []string{"1", "2", "3"}, want: []string{"1", "2", "3"}},
+		{name: "oldest are dropped", capacity: 3, published: []string{"1", "2", "3", "4", "5"},
+			want: []string{"3", "4", "5"}},
+		{name: "wrapped twice", capacity: 2, published: []string{"1", "2", "3", "4", "5"}, want: []string{"4", "5"}},
+		{name: "zero capacity", capacity: 0, published: []string{"1"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewMemoryPublisher(tt.capacity)
+			for _, uuid := range tt.published {
+				if err := p.Publish(context.Background(), entity.OutboxEvent{UUID: uuid}); err != nil {
+					t.Fatalf("Publish() error = %v", err)
+				}
+			}
+
+			got := p.Events()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Events() returned %d events, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i].UUID != tt.want[i] {
+					t.Errorf("event %d = %q, want %q", i, got[i].UUID, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/pkg/webhook"
+)
+
+// WebhookPublisher posts events to a single URL, signed the same way as user webhooks.
+type WebhookPublisher struct {
+	sender *webhook.Sender
+	url    string
+	secret string
+}
+
+func NewWebhookPublisher(sender *webhook.Sender, url, secret string) *WebhookPublisher {
+	return &WebhookPublisher{
+		sender: sender,
+		url:    url,
+		secret: secret,
+	}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event entity.OutboxEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	_, err = p.sender.Send(ctx, p.url, p.secret, event.Event, event.UUID, payload)
+	return err
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+)
+
+type accountRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewAccountRepo(client postgresql.Client, logger *logging.Logger) service.AccountRepo {
+	return &accountRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *accountRepo) Create(ctx context.Context, account entity.Account) (string, error) {
+	query := `
+				INSERT INTO accounts
+					(user_id, name, currency)
+				VALUES
+					($1, $2, $3)
+				RETURNING id;
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var accountUUID string
+	err := r.client.QueryRow(nCtx, query, account.UserUUID, account.Name, account.Currency).Scan(&accountUUID)
+	if err != nil {
+		return "", handleSQLError(err, r.logger)
+	}
+
+	return accountUUID, nil
+}
+
+func (r *accountRepo) FindByUUID(ctx context.Context, uuid string) (entity.Account, error) {
+	query := `
+				SELECT
+					id, user_id, name, currency
+				FROM
+					accounts
+				WHERE
+					id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var account entity.Account
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&account.UUID, &account.UserUUID, &account.Name,
+		&account.Currency)
+	if err != nil {
+		return entity.Account{}, handleSQLError(err, r.logger)
+	}
+
+	return account, nil
+}
+
+func (r *accountRepo) FindByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) ([]entity.Account, error) {
+	var where whereClause
+	where.add("user_id = $%d", uuid)
+	if page.After != nil {
+		where.add("id > $%d", page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT
+					id, user_id, name, currency
+				FROM
+					accounts
+				%s
+				ORDER BY
+					id
+				LIMIT $%d
+	`, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	accounts := make([]entity.Account, 0)
+	for rows.Next() {
+		var account entity.Account
+		err = rows.Scan(&account.UUID, &account.UserUUID, &account.Name, &account.Currency)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *accountRepo) Balance(ctx context.Context, uuid string) (entity.AccountBalance, error) {
+	query := `
+				SELECT
+					a.id, a.currency, COALESCE(SUM(o.money_sum), 0)
+				FROM
+					accounts a
+				LEFT JOIN
+					operations o ON o.account_id = a.id AND o.deleted_at IS NULL
+				WHERE
+					a.id = $1
+				GROUP BY
+					a.id, a.currency
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var balance entity.AccountBalance
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&balance.AccountUUID, &balance.Currency, &balance.Balance)
+	if err != nil {
+		return entity.AccountBalance{}, handleSQLError(err, r.logger)
+	}
+
+	return balance, nil
+}
+
+func (r *accountRepo) HasOperations(ctx context.Context, uuid string) (bool, error) {
+	query := `
+				SELECT EXISTS (
+					-- deleted operations reference the account until they are purged
+					SELECT 1 FROM operations WHERE account_id = $1
+				)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var exists bool
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&exists)
+	if err != nil {
+		return false, handleSQLError(err, r.logger)
+	}
+
+	return exists, nil
+}
+
+// HasRecurringOperations reports whether the account is used by recurring operations, including
+// those of deleted categories, which are kept until the categories are purged.
+func (r *accountRepo) HasRecurringOperations(ctx context.Context, uuid string) (bool, error) {
+	query := `
+				SELECT EXISTS (
+					SELECT 1 FROM recurring_operations WHERE account_id = $1
+				)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var exists bool
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&exists)
+	if err != nil {
+		return false, handleSQLError(err, r.logger)
+	}
+
+	return exists, nil
+}
+
+func (r *accountRepo) Update(ctx context.Context, account entity.Account) error {
+	query := `
+				UPDATE
+					accounts
+				SET
+					name = $1
+				WHERE
+					id = $2
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, account.Name, account.UUID)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *accountRepo) Delete(ctx context.Context, uuid string) error {
+	query := `
+				DELETE FROM
+					accounts
+				WHERE
+					id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+)
+
+type auditRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewAuditRepo(client postgresql.Client, logger *logging.Logger) service.AuditRepo {
+	return &auditRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *auditRepo) Save(ctx context.Context, record entity.AuditRecord) error {
+	changes, err := json.Marshal(record.Changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit changes: %w", err)
+	}
+
+	query := `
+				INSERT INTO audit_log
+					(entity_type, entity_id, action, actor, request_id, changes)
+				VALUES
+					($1, $2, $3, $4, $5, $6)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	_, err = r.client.Exec(nCtx, query, record.EntityType, record.EntityUUID, record.Action, record.Actor,
+		record.RequestID, string(changes))
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+	return nil
+}
+
+// FindByEntity returns the changes of the entity starting from the latest one.
+func (r *auditRepo) FindByEntity(ctx context.Context, entityType, uuid string,
+	page pagination.Params) ([]entity.AuditRecord, error) {
+	var where whereClause
+	where.add("entity_type = $%d", entityType)
+	where.add("entity_id = $%d", uuid)
+	if page.After != nil {
+		where.add("(created_at, id) < ($%d, $%d::uuid)", *page.After.DateTime, page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT
+					id, entity_type, entity_id, action, actor, request_id, changes, created_at
+				FROM
+					audit_log
+				%s
+				ORDER BY
+					created_at DESC, id DESC
+				LIMIT $%d
+	`, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	records := make([]entity.AuditRecord, 0)
+	for rows.Next() {
+		var (
+			record  entity.AuditRecord
+			changes []byte
+		)
+		err = rows.Scan(&record.UUID, &record.EntityType, &record.EntityUUID, &record.Action, &record.Actor,
+			&record.RequestID, &changes, &record.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(changes, &record.Changes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal audit changes: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+	"time"
+)
+
+type budgetRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewBudgetRepo(client postgresql.Client, logger *logging.Logger) service.BudgetRepo {
+	return &budgetRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *budgetRepo) Create(ctx context.Context, budget entity.Budget) (string, error) {
+	query := `
+				INSERT INTO budgets
+					(category_id, period, money_limit, currency, rollover)
+				VALUES
+					($1, $2, $3, $4, $5)
+				RETURNING id;
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var budgetUUID string
+	err := r.client.QueryRow(nCtx, query, budget.CategoryUUID, budget.Period, budget.Limit, budget.Currency,
+		budget.Rollover).Scan(&budgetUUID)
+	if err != nil {
+		return "", handleSQLError(err, r.logger)
+	}
+
+	return budgetUUID, nil
+}
+
+func (r *budgetRepo) FindByUUID(ctx context.Context, uuid string) (entity.Budget, error) {
+	query := `
+				SELECT
+					b.id, c.user_id, b.category_id, b.period, b.money_limit, b.currency, b.rollover
+				FROM
+					budgets b
+				JOIN
+					categories c ON c.id = b.category_id
+				WHERE
+					b.id = $1 AND c.deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var budget entity.Budget
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&budget.UUID, &budget.UserUUID, &budget.CategoryUUID,
+		&budget.Period, &budget.Limit, &budget.Currency, &budget.Rollover)
+	if err != nil {
+		return entity.Budget{}, handleSQLError(err, r.logger)
+	}
+
+	return budget, nil
+}
+
+func (r *budgetRepo) FindByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) ([]entity.Budget, error) {
+	var where whereClause
+	where.add("c.user_id = $%d", uuid)
+	where.add("c.deleted_at IS NULL")
+	if page.After != nil {
+		where.add("b.id > $%d", page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT
+					b.id, c.user_id, b.category_id, b.period, b.money_limit, b.currency, b.rollover
+				FROM
+					budgets b
+				JOIN
+					categories c ON c.id = b.category_id
+				%s
+				ORDER BY
+					b.id
+				LIMIT $%d
+	`, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	budgets := make([]entity.Budget, 0)
+	for rows.Next() {
+		var budget entity.Budget
+		err = rows.Scan(&budget.UUID, &budget.UserUUID, &budget.CategoryUUID, &budget.Period, &budget.Limit,
+			&budget.Currency, &budget.Rollover)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return budgets, nil
+}
+
+func (r *budgetRepo) FindByCategoryUUID(ctx context.Context, uuid string) ([]entity.Budget, error) {
+	query := `
+				SELECT
+					b.id, c.user_id, b.category_id, b.period, b.money_limit, b.currency, b.rollover
+				FROM
+					budgets b
+				JOIN
+					categories c ON c.id = b.category_id
+				WHERE
+					b.category_id = $1 AND c.deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, uuid)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	budgets := make([]entity.Budget, 0)
+	for rows.Next() {
+		var budget entity.Budget
+		err = rows.Scan(&budget.UUID, &budget.UserUUID, &budget.CategoryUUID, &budget.Period, &budget.Limit,
+			&budget.Currency, &budget.Rollover)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, budget)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return budgets, nil
+}
+
+// SaveAlert records that spending of the budget reached the threshold in the period.
+// It returns false if the alert has already been recorded.
+func (r *budgetRepo) SaveAlert(ctx context.Context, uuid string, periodStart time.Time,
+	threshold int) (bool, error) {
+	query := `
+				INSERT INTO budget_alerts
+					(budget_id, period_start, threshold)
+				VALUES
+					($1, $2, $3)
+				ON CONFLICT DO NOTHING
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid, periodStart, threshold)
+	if err != nil {
+		return false, handleSQLError(err, r.logger)
+	}
+
+	return cmdTag.RowsAffected() > 0, nil
+}
+
+func (r *budgetRepo) Update(ctx context.Context, budget entity.Budget) error {
+	query := `
+				UPDATE
+					budgets
+				SET
+					period = $1, money_limit = $2, rollover = $3
+				WHERE
+					id = $4
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, budget.Period, budget.Limit, budget.Rollover, budget.UUID)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *budgetRepo) Delete(ctx context.Context, uuid string) error {
+	query := `
+				DELETE FROM
+					budgets
+				WHERE
+					id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
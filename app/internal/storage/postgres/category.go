@@ -10,6 +10,7 @@ import (
 	"operation-service/internal/domain/entity"
 	"operation-service/internal/domain/service"
 	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
 	"operation-service/pkg/postgresql"
 	"operation-service/pkg/utils"
 	"time"
@@ -17,6 +18,13 @@ import (
 
 const queryWaitTime = 5 * time.Second
 
+const (
+	uniqueViolationCode = "23505"
+	checkViolationCode  = "23514"
+
+	categoryUniqueConstraint = "categories_user_name_type_key"
+)
+
 type categoryRepo struct {
 	client postgresql.Client
 	logger *logging.Logger
@@ -40,9 +48,15 @@ func handleSQLError(err error, logger *logging.Logger) error {
 			pgErr.Message, pgErr.Detail, pgErr.Where, pgErr.Code, pgErr.SQLState())
 		logger.Error(newErr)
 
-		//if pgErr.Code == "23505" { //uniqueness violation
-		//	return apperror.BadRequestError("")
-		//}
+		switch pgErr.Code {
+		case uniqueViolationCode:
+			if pgErr.ConstraintName == categoryUniqueConstraint {
+				return apperror.ConflictError("category already exists")
+			}
+			return apperror.ConflictError("resource already exists")
+		case checkViolationCode:
+			return apperror.ValidationError("invalid value")
+		}
 		return newErr
 	}
 
@@ -52,9 +66,9 @@ func handleSQLError(err error, logger *logging.Logger) error {
 func (r *categoryRepo) Create(ctx context.Context, category entity.Category) (string, error) {
 	query := `
 				INSERT INTO categories
-					(user_id, name, type)
+					(user_id, name, type, currency, parent_id)
 				VALUES
-					($1, $2, $3)
+					($1, $2, $3, NULLIF($4, ''), NULLIF($5, '')::uuid)
 				RETURNING id;
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
@@ -63,7 +77,8 @@ func (r *categoryRepo) Create(ctx context.Context, category entity.Category) (st
 	defer cancel()
 
 	var categoryUUID string
-	err := r.client.QueryRow(nCtx, query, category.UserUUID, category.Name, category.Type).Scan(&categoryUUID)
+	err := r.client.QueryRow(nCtx, query, category.UserUUID, category.Name, category.Type,
+		category.Currency, category.ParentUUID).Scan(&categoryUUID)
 	if err != nil {
 		return "", handleSQLError(err, r.logger)
 	}
@@ -74,11 +89,11 @@ func (r *categoryRepo) Create(ctx context.Context, category entity.Category) (st
 func (r *categoryRepo) FindByUUID(ctx context.Context, uuid string) (entity.Category, error) {
 	query := `
 				SELECT
-					id, user_id, name, type
+					id, user_id, name, type, COALESCE(currency, ''), COALESCE(parent_id::text, '')
 				FROM
 					categories
 				WHERE
-					id = $1
+					id = $1 AND deleted_at IS NULL
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
@@ -86,7 +101,8 @@ func (r *categoryRepo) FindByUUID(ctx context.Context, uuid string) (entity.Cate
 	defer cancel()
 
 	var category entity.Category
-	err := r.client.QueryRow(nCtx, query, uuid).Scan(&category.UUID, &category.UserUUID, &category.Name, &category.Type)
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&category.UUID, &category.UserUUID, &category.Name, &category.Type,
+		&category.Currency, &category.ParentUUID)
 	if err != nil {
 		return entity.Category{}, handleSQLError(err, r.logger)
 	}
@@ -94,14 +110,65 @@ func (r *categoryRepo) FindByUUID(ctx context.Context, uuid string) (entity.Cate
 	return category, nil
 }
 
-func (r *categoryRepo) FindByUserUUID(ctx context.Context, uuid string) ([]entity.Category, error) {
+func (r *categoryRepo) FindByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) ([]entity.Category, error) {
+	var where whereClause
+	where.add("user_id = $%d", uuid)
+	where.add("deleted_at IS NULL")
+	if page.After != nil {
+		where.add("id > $%d", page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT
+					id, user_id, name, type, COALESCE(currency, ''), COALESCE(parent_id::text, '')
+				FROM
+					categories
+				%s
+				ORDER BY
+					id
+				LIMIT $%d
+	`, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	categories := make([]entity.Category, 0)
+	for rows.Next() {
+		var category entity.Category
+		err = rows.Scan(&category.UUID, &category.UserUUID, &category.Name, &category.Type, &category.Currency,
+			&category.ParentUUID)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// FindAllByUserUUID returns all categories of the user ordered by name.
+func (r *categoryRepo) FindAllByUserUUID(ctx context.Context, uuid string) ([]entity.Category, error) {
 	query := `
 				SELECT
-					id, user_id, name, type
+					id, user_id, name, type, COALESCE(currency, ''), COALESCE(parent_id::text, '')
 				FROM
 					categories
 				WHERE
-				    user_id = $1
+					user_id = $1 AND deleted_at IS NULL
+				ORDER BY
+					name, id
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
@@ -117,7 +184,8 @@ func (r *categoryRepo) FindByUserUUID(ctx context.Context, uuid string) ([]entit
 	categories := make([]entity.Category, 0)
 	for rows.Next() {
 		var category entity.Category
-		err = rows.Scan(&category.UUID, &category.UserUUID, &category.Name, &category.Type)
+		err = rows.Scan(&category.UUID, &category.UserUUID, &category.Name, &category.Type, &category.Currency,
+			&category.ParentUUID)
 		if err != nil {
 			return nil, err
 		}
@@ -130,37 +198,160 @@ func (r *categoryRepo) FindByUserUUID(ctx context.Context, uuid string) ([]entit
 	return categories, nil
 }
 
+func (r *categoryRepo) HasChildren(ctx context.Context, uuid string) (bool, error) {
+	query := `
+				SELECT EXISTS (
+					SELECT 1 FROM categories WHERE parent_id = $1 AND deleted_at IS NULL
+				)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var hasChildren bool
+	if err := r.client.QueryRow(nCtx, query, uuid).Scan(&hasChildren); err != nil {
+		return false, handleSQLError(err, r.logger)
+	}
+	return hasChildren, nil
+}
+
+func (r *categoryRepo) HasOperations(ctx context.Context, uuid string) (bool, error) {
+	query := `
+				SELECT EXISTS (
+					SELECT 1 FROM operations WHERE category_id = $1 AND deleted_at IS NULL
+				)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var hasOperations bool
+	if err := r.client.QueryRow(nCtx, query, uuid).Scan(&hasOperations); err != nil {
+		return false, handleSQLError(err, r.logger)
+	}
+	return hasOperations, nil
+}
+
+// ReassignOperations moves operations and recurring operations of a category to another one
+// and returns the moved operations. Deleted operations stay in the category, so they are
+// restored only together with it.
+func (r *categoryRepo) ReassignOperations(ctx context.Context, fromUUID, toUUID string) ([]entity.Operation, error) {
+	query := `
+				UPDATE
+					operations
+				SET
+					category_id = $1
+				WHERE
+					category_id = $2 AND deleted_at IS NULL
+				RETURNING
+					id, category_id, COALESCE(account_id::text, ''), money_sum, currency, description, date_time
+	`
+	operations, err := r.updateOperations(ctx, query, toUUID, fromUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+				UPDATE
+					recurring_operations
+				SET
+					category_id = $1
+				WHERE
+					category_id = $2
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	if _, err = r.client.Exec(nCtx, query, toUUID, fromUUID); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	return operations, nil
+}
+
+// DeleteOperations deletes operations of a category and returns them as they were before deletion.
+func (r *categoryRepo) DeleteOperations(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	query := `
+				UPDATE
+					operations
+				SET
+					deleted_at = now()
+				WHERE
+					category_id = $1 AND deleted_at IS NULL
+				RETURNING
+					id, category_id, COALESCE(account_id::text, ''), money_sum, currency, description, date_time
+	`
+	return r.updateOperations(ctx, query, uuid)
+}
+
+// updateOperations runs the query updating operations and scans the operations it returns.
+func (r *categoryRepo) updateOperations(ctx context.Context, query string, args ...any) ([]entity.Operation, error) {
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	operations := make([]entity.Operation, 0)
+	for rows.Next() {
+		var operation entity.Operation
+		err = rows.Scan(&operation.UUID, &operation.CategoryUUID, &operation.AccountUUID, &operation.MoneySum,
+			&operation.Currency, &operation.Description, &operation.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	return operations, nil
+}
+
 func (r *categoryRepo) Update(ctx context.Context, category entity.Category) error {
 	query := `
 				UPDATE 
 					categories
 				SET 
-    				name = $1
+    				name = $1, currency = NULLIF($2, ''), parent_id = NULLIF($3, '')::uuid
 				WHERE
-				    id = $2
+				    id = $4 AND deleted_at IS NULL
 	`
-	r.logger.Trace("SQL query: %s", utils.FormatSQLQuery(query))
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
 	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
 	defer cancel()
 
-	cmdTag, err := r.client.Exec(nCtx, query, category.Name, category.UUID)
+	cmdTag, err := r.client.Exec(nCtx, query, category.Name, category.Currency, category.ParentUUID, category.UUID)
 	if err != nil {
 		return handleSQLError(err, r.logger)
 	}
 
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows were updated")
+		return apperror.ErrNotFound
 	}
 	return nil
 }
 
+// Delete marks the category deleted. Operations deleted with the category in the same transaction
+// get the same deletion time, which tells them apart on restore.
 func (r *categoryRepo) Delete(ctx context.Context, uuid string) error {
 	query := `
-				DELETE FROM
+				UPDATE
 					categories
+				SET
+					deleted_at = now()
 				WHERE
-					id = $1
+					id = $1 AND deleted_at IS NULL
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
@@ -173,7 +364,146 @@ func (r *categoryRepo) Delete(ctx context.Context, uuid string) error {
 	}
 
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows were deleted")
+		return apperror.ErrNotFound
 	}
 	return nil
 }
+
+func (r *categoryRepo) FindDeletedByUUID(ctx context.Context, uuid string) (entity.Category, error) {
+	query := `
+				SELECT
+					id, user_id, name, type, COALESCE(currency, ''), COALESCE(parent_id::text, '')
+				FROM
+					categories
+				WHERE
+					id = $1 AND deleted_at IS NOT NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var category entity.Category
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&category.UUID, &category.UserUUID, &category.Name, &category.Type,
+		&category.Currency, &category.ParentUUID)
+	if err != nil {
+		return entity.Category{}, handleSQLError(err, r.logger)
+	}
+
+	return category, nil
+}
+
+// Restore restores the category together with the operations deleted with it and returns
+// the restored operations.
+func (r *categoryRepo) Restore(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	query := `
+				UPDATE
+					operations o
+				SET
+					deleted_at = NULL
+				FROM
+					categories c
+				WHERE
+					c.id = $1 AND o.category_id = c.id AND o.deleted_at = c.deleted_at
+				RETURNING
+					o.id, o.category_id, COALESCE(o.account_id::text, ''), o.money_sum, o.currency, o.description,
+					o.date_time
+	`
+	operations, err := r.updateOperations(ctx, query, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+				UPDATE
+					categories
+				SET
+					deleted_at = NULL
+				WHERE
+					id = $1 AND deleted_at IS NOT NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return nil, apperror.ErrNotFound
+	}
+	return operations, nil
+}
+
+// Purge removes categories deleted before the given time and returns their number. Categories
+// still referenced by operations or subcategories are left to the next purge, after those are removed.
+// Recurring operations and budgets of the removed categories are removed with them. It must be
+// called within a transaction, which keeps the removed categories from being restored meanwhile.
+func (r *categoryRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	uuids, err := r.lockPurgeable(ctx, deletedBefore)
+	if err != nil || len(uuids) == 0 {
+		return 0, err
+	}
+
+	queries := []string{
+		`DELETE FROM recurring_operations WHERE category_id = ANY($1::uuid[])`,
+		`DELETE FROM budgets WHERE category_id = ANY($1::uuid[])`,
+		`DELETE FROM categories WHERE id = ANY($1::uuid[])`,
+	}
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var cmdTag pgconn.CommandTag
+	for _, query := range queries {
+		r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+		if cmdTag, err = r.client.Exec(nCtx, query, uuids); err != nil {
+			return 0, handleSQLError(err, r.logger)
+		}
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// lockPurgeable returns uuids of categories which can be purged and locks them until the end
+// of the transaction carried by ctx.
+func (r *categoryRepo) lockPurgeable(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	query := `
+				SELECT
+					c.id
+				FROM
+					categories c
+				WHERE
+					c.deleted_at < $1
+					AND NOT EXISTS (SELECT 1 FROM operations WHERE category_id = c.id)
+					AND NOT EXISTS (SELECT 1 FROM categories WHERE parent_id = c.id)
+				FOR UPDATE
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, deletedBefore)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	uuids := make([]string, 0)
+	for rows.Next() {
+		var uuid string
+		if err = rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		uuids = append(uuids, uuid)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	return uuids, nil
+}
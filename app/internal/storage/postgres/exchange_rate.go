@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+	"time"
+)
+
+type exchangeRateRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewExchangeRateRepo(client postgresql.Client, logger *logging.Logger) service.ExchangeRateRepo {
+	return &exchangeRateRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *exchangeRateRepo) Save(ctx context.Context, rates []entity.ExchangeRate) error {
+	query := `
+				INSERT INTO exchange_rates
+					(base, quote, date, rate)
+				SELECT
+					base, quote, date::date, rate::numeric
+				FROM
+					unnest($1::text[], $2::text[], $3::text[], $4::text[]) AS r(base, quote, date, rate)
+				ON CONFLICT (base, quote, date) DO UPDATE SET
+					rate = EXCLUDED.rate
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	bases := make([]string, len(rates))
+	quotes := make([]string, len(rates))
+	dates := make([]string, len(rates))
+	values := make([]string, len(rates))
+	for i, rate := range rates {
+		bases[i] = string(rate.Base)
+		quotes[i] = string(rate.Quote)
+		dates[i] = rate.Date.Format(entity.DateLayout)
+		values[i] = rate.Rate.String()
+	}
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	_, err := r.client.Exec(nCtx, query, bases, quotes, dates, values)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+	return nil
+}
+
+// Rate returns the latest known rate on or before date. A rate stored for the
+// opposite direction is inverted.
+func (r *exchangeRateRepo) Rate(ctx context.Context, base, quote types.Currency, date time.Time) (types.Rate, error) {
+	if base == quote {
+		return types.OneRate(), nil
+	}
+
+	query := `
+				SELECT
+					rate
+				FROM (
+					SELECT rate, date FROM exchange_rates WHERE base = $1 AND quote = $2 AND date <= $3
+					UNION ALL
+					SELECT 1 / rate, date FROM exchange_rates WHERE base = $2 AND quote = $1 AND date <= $3
+				) AS r
+				ORDER BY
+					date DESC
+				LIMIT 1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var rate types.Rate
+	err := r.client.QueryRow(nCtx, query, base, quote, date.Format(entity.DateLayout)).Scan(&rate)
+	if err != nil {
+		return types.Rate{}, handleSQLError(err, r.logger)
+	}
+	return rate, nil
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+)
+
+// whereClause accumulates query conditions together with their arguments.
+// Every condition must contain one %d verb per argument, which is replaced by the
+// number of the argument's positional parameter.
+type whereClause struct {
+	conditions []string
+	args       []interface{}
+}
+
+func (w *whereClause) add(condition string, args ...interface{}) {
+	positions := make([]interface{}, len(args))
+	for i, arg := range args {
+		positions[i] = w.param(arg)
+	}
+	w.conditions = append(w.conditions, fmt.Sprintf(condition, positions...))
+}
+
+// param appends an argument that is not part of the conditions (e.g. LIMIT)
+// and returns the number of its positional parameter.
+func (w *whereClause) param(arg interface{}) int {
+	w.args = append(w.args, arg)
+	return len(w.args)
+}
+
+func (w *whereClause) String() string {
+	if len(w.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(w.conditions, " AND ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -3,11 +3,14 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"operation-service/internal/apperror"
 	"operation-service/internal/domain/entity"
 	"operation-service/internal/domain/service"
+	"operation-service/internal/domain/types"
 	"operation-service/pkg/logging"
 	"operation-service/pkg/postgresql"
 	"operation-service/pkg/utils"
+	"time"
 )
 
 type operationRepo struct {
@@ -25,9 +28,9 @@ func NewOperationRepo(client postgresql.Client, logger *logging.Logger) service.
 func (r *operationRepo) Create(ctx context.Context, operation entity.Operation) (string, error) {
 	query := `
 				INSERT INTO operations
-					(category_id, money_sum, description, date_time)
-				VALUES 
-					($1, $2, $3, $4)
+					(category_id, account_id, transfer_id, money_sum, currency, description, date_time)
+				VALUES
+					(NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid, $4, $5, $6, $7)
 				RETURNING id;
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
@@ -36,9 +39,8 @@ func (r *operationRepo) Create(ctx context.Context, operation entity.Operation)
 	defer cancel()
 
 	var operationUUID string
-	r.logger.Debug(operation.CategoryUUID)
-	err := r.client.QueryRow(nCtx, query, operation.CategoryUUID, operation.MoneySum, operation.Description,
-		operation.DateTime).Scan(&operationUUID)
+	err := r.client.QueryRow(nCtx, query, operation.CategoryUUID, operation.AccountUUID, operation.TransferUUID,
+		operation.MoneySum, operation.Currency, operation.Description, operation.DateTime).Scan(&operationUUID)
 	if err != nil {
 		return "", handleSQLError(err, r.logger)
 	}
@@ -49,11 +51,17 @@ func (r *operationRepo) Create(ctx context.Context, operation entity.Operation)
 func (r *operationRepo) FindByUUID(ctx context.Context, uuid string) (entity.Operation, error) {
 	query := `
 				SELECT
-					id, category_id, money_sum, description, date_time
+					o.id, COALESCE(c.user_id, a.user_id), COALESCE(o.category_id::text, ''),
+					COALESCE(o.account_id::text, ''), COALESCE(o.transfer_id::text, ''), o.money_sum, o.currency,
+					o.description, o.date_time
 				FROM
-					operations
+					operations o
+				LEFT JOIN
+					categories c ON c.id = o.category_id
+				LEFT JOIN
+					accounts a ON a.id = o.account_id
 				WHERE
-					id = $1
+					o.id = $1 AND o.deleted_at IS NULL
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
@@ -61,7 +69,8 @@ func (r *operationRepo) FindByUUID(ctx context.Context, uuid string) (entity.Ope
 	defer cancel()
 
 	var operation entity.Operation
-	err := r.client.QueryRow(nCtx, query, uuid).Scan(&operation.UUID, &operation.CategoryUUID, &operation.MoneySum,
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&operation.UUID, &operation.UserUUID, &operation.CategoryUUID,
+		&operation.AccountUUID, &operation.TransferUUID, &operation.MoneySum, &operation.Currency,
 		&operation.Description, &operation.DateTime)
 	if err != nil {
 		return entity.Operation{}, handleSQLError(err, r.logger)
@@ -70,38 +79,394 @@ func (r *operationRepo) FindByUUID(ctx context.Context, uuid string) (entity.Ope
 	return operation, nil
 }
 
+func (r *operationRepo) FindByTransferUUID(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	query := `
+				SELECT
+					id, COALESCE(account_id::text, ''), transfer_id, money_sum, currency, description, date_time
+				FROM
+					operations
+				WHERE
+					transfer_id = $1 AND deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, uuid)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	operations := make([]entity.Operation, 0)
+	for rows.Next() {
+		var operation entity.Operation
+		err = rows.Scan(&operation.UUID, &operation.AccountUUID, &operation.TransferUUID, &operation.MoneySum,
+			&operation.Currency, &operation.Description, &operation.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
+func (r *operationRepo) Find(ctx context.Context, filter entity.OperationFilter) ([]entity.Operation, error) {
+	var where whereClause
+	where.add("o.deleted_at IS NULL")
+	if filter.UserUUID != "" {
+		// transfer operations have no category and belong to the user of their account
+		where.add("COALESCE(c.user_id, a.user_id) = $%d", filter.UserUUID)
+	}
+	if len(filter.CategoryUUIDs) > 0 {
+		where.add("o.category_id = ANY($%d::uuid[])", filter.CategoryUUIDs)
+	}
+	if filter.AccountUUID != "" {
+		where.add("o.account_id = $%d", filter.AccountUUID)
+	}
+	if filter.DateFrom != nil {
+		where.add("o.date_time >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		where.add("o.date_time <= $%d", *filter.DateTo)
+	}
+	if filter.MinSum != nil {
+		where.add("ABS(o.money_sum) >= $%d", *filter.MinSum)
+	}
+	if filter.MaxSum != nil {
+		where.add("ABS(o.money_sum) <= $%d", *filter.MaxSum)
+	}
+	if filter.Currency != "" {
+		where.add("o.currency = $%d", filter.Currency)
+	}
+	if filter.Description != "" {
+		where.add("o.description ILIKE '%%' || $%d || '%%'", escapeLike(filter.Description))
+	}
+	if filter.After != nil {
+		where.add("(o.date_time, o.id) < ($%d, $%d::uuid)", *filter.After.DateTime, filter.After.UUID)
+	}
+	limit := where.param(filter.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT
+					o.id, COALESCE(c.user_id, a.user_id), COALESCE(o.category_id::text, ''),
+					COALESCE(o.account_id::text, ''), COALESCE(o.transfer_id::text, ''), o.money_sum, o.currency,
+					o.description, o.date_time
+				FROM
+					operations o
+				LEFT JOIN
+					categories c ON c.id = o.category_id
+				LEFT JOIN
+					accounts a ON a.id = o.account_id
+				%s
+				ORDER BY
+					o.date_time DESC, o.id DESC
+				LIMIT $%d
+	`, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	operations := make([]entity.Operation, 0)
+	for rows.Next() {
+		var operation entity.Operation
+		err = rows.Scan(&operation.UUID, &operation.UserUUID, &operation.CategoryUUID, &operation.AccountUUID,
+			&operation.TransferUUID, &operation.MoneySum, &operation.Currency, &operation.Description,
+			&operation.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return operations, nil
+}
+
+// Balance sums operations of categories, transfers between accounts have no category and do not count
+// as income or expense. The same holds for DailyBalance and SumByCategory.
+func (r *operationRepo) Balance(ctx context.Context, filter entity.BalanceFilter) ([]entity.Balance, error) {
+	var where whereClause
+	where.add("c.user_id = $%d", filter.UserUUID)
+	where.add("o.deleted_at IS NULL")
+	if filter.CategoryUUID != "" {
+		where.add("o.category_id = $%d", filter.CategoryUUID)
+	}
+	if filter.DateFrom != nil {
+		where.add("o.date_time >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		where.add("o.date_time <= $%d", *filter.DateTo)
+	}
+
+	query := fmt.Sprintf(`
+				SELECT
+					o.currency,
+					COALESCE(SUM(o.money_sum) FILTER (WHERE c.type = '%s'), 0),
+					COALESCE(-SUM(o.money_sum) FILTER (WHERE c.type = '%s'), 0),
+					SUM(o.money_sum)
+				FROM
+					operations o
+				JOIN
+					categories c ON c.id = o.category_id
+				%s
+				GROUP BY
+					o.currency
+				ORDER BY
+					o.currency
+	`, types.IncomeType, types.ExpenseType, where.String())
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	balances := make([]entity.Balance, 0)
+	for rows.Next() {
+		var balance entity.Balance
+		err = rows.Scan(&balance.Currency, &balance.Income, &balance.Expense, &balance.Net)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *operationRepo) DailyBalance(ctx context.Context, filter entity.BalanceFilter) ([]entity.DailyBalance, error) {
+	var where whereClause
+	where.add("c.user_id = $%d", filter.UserUUID)
+	where.add("o.deleted_at IS NULL")
+	if filter.CategoryUUID != "" {
+		where.add("o.category_id = $%d", filter.CategoryUUID)
+	}
+	if filter.DateFrom != nil {
+		where.add("o.date_time >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		where.add("o.date_time <= $%d", *filter.DateTo)
+	}
+
+	query := fmt.Sprintf(`
+				SELECT
+					o.date_time::date AS day,
+					o.currency,
+					COALESCE(SUM(o.money_sum) FILTER (WHERE c.type = '%s'), 0),
+					COALESCE(-SUM(o.money_sum) FILTER (WHERE c.type = '%s'), 0),
+					SUM(o.money_sum)
+				FROM
+					operations o
+				JOIN
+					categories c ON c.id = o.category_id
+				%s
+				GROUP BY
+					day, o.currency
+				ORDER BY
+					day, o.currency
+	`, types.IncomeType, types.ExpenseType, where.String())
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	balances := make([]entity.DailyBalance, 0)
+	for rows.Next() {
+		var balance entity.DailyBalance
+		err = rows.Scan(&balance.Day, &balance.Currency, &balance.Income, &balance.Expense, &balance.Net)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, balance)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+func (r *operationRepo) SumByCategory(ctx context.Context,
+	filter entity.ReportFilter) ([]entity.CategoryReportRow, error) {
+	var where whereClause
+	where.add("c.user_id = $%d", filter.UserUUID)
+	where.add("o.deleted_at IS NULL")
+	if filter.DateFrom != nil {
+		where.add("o.date_time >= $%d", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		where.add("o.date_time <= $%d", *filter.DateTo)
+	}
+	bucket := fmt.Sprintf("date_trunc($%d, o.date_time)", where.param(string(filter.Bucket)))
+	day := bucket
+	if filter.ByDay {
+		day = "o.date_time"
+	}
+
+	query := fmt.Sprintf(`
+				SELECT
+					c.id, c.name, c.type, COALESCE(c.parent_id::text, ''), o.currency,
+					%s AS bucket,
+					(%s)::date AS day,
+					COALESCE(SUM(o.money_sum) FILTER (WHERE c.type = '%s'), 0),
+					COALESCE(-SUM(o.money_sum) FILTER (WHERE c.type = '%s'), 0)
+				FROM
+					operations o
+				JOIN
+					categories c ON c.id = o.category_id
+				%s
+				GROUP BY
+					c.id, c.name, c.type, c.parent_id, o.currency, bucket, day
+				ORDER BY
+					c.name, c.id, bucket, day, o.currency
+	`, bucket, day, types.IncomeType, types.ExpenseType, where.String())
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	reportRows := make([]entity.CategoryReportRow, 0)
+	for rows.Next() {
+		var row entity.CategoryReportRow
+		err = rows.Scan(&row.CategoryUUID, &row.CategoryName, &row.CategoryType, &row.ParentUUID, &row.Currency,
+			&row.Start, &row.Day, &row.Income, &row.Expense)
+		if err != nil {
+			return nil, err
+		}
+		reportRows = append(reportRows, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return reportRows, nil
+}
+
 func (r *operationRepo) Update(ctx context.Context, operation entity.Operation) error {
 	query := `
 				UPDATE
 					operations
 				SET
-					category_id = $1, money_sum = $2, description = $3
+					category_id = NULLIF($1, '')::uuid, account_id = NULLIF($2, '')::uuid, money_sum = $3, currency = $4,
+					description = $5, date_time = $6
 				WHERE
-					id = $4 
+					id = $7 AND deleted_at IS NULL
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
 	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
 	defer cancel()
 
-	cmdTag, err := r.client.Exec(nCtx, query, operation.CategoryUUID, operation.MoneySum, operation.Description,
-		operation.UUID)
+	cmdTag, err := r.client.Exec(nCtx, query, operation.CategoryUUID, operation.AccountUUID, operation.MoneySum,
+		operation.Currency, operation.Description, operation.DateTime, operation.UUID)
 	if err != nil {
 		return handleSQLError(err, r.logger)
 	}
 
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows were updated")
+		return apperror.ErrNotFound
 	}
 	return nil
 }
 
 func (r *operationRepo) Delete(ctx context.Context, uuid string) error {
 	query := `
-				DELETE FROM
+				UPDATE
+					operations
+				SET
+					deleted_at = now()
+				WHERE
+				    id = $1 AND deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *operationRepo) FindDeletedByUUID(ctx context.Context, uuid string) (entity.Operation, error) {
+	query := `
+				SELECT
+					o.id, COALESCE(c.user_id, a.user_id), COALESCE(o.category_id::text, ''),
+					COALESCE(o.account_id::text, ''), COALESCE(o.transfer_id::text, ''), o.money_sum, o.currency,
+					o.description, o.date_time
+				FROM
+					operations o
+				LEFT JOIN
+					categories c ON c.id = o.category_id
+				LEFT JOIN
+					accounts a ON a.id = o.account_id
+				WHERE
+					o.id = $1 AND o.deleted_at IS NOT NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var operation entity.Operation
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&operation.UUID, &operation.UserUUID, &operation.CategoryUUID,
+		&operation.AccountUUID, &operation.TransferUUID, &operation.MoneySum, &operation.Currency,
+		&operation.Description, &operation.DateTime)
+	if err != nil {
+		return entity.Operation{}, handleSQLError(err, r.logger)
+	}
+
+	return operation, nil
+}
+
+func (r *operationRepo) Restore(ctx context.Context, uuid string) error {
+	query := `
+				UPDATE
 					operations
+				SET
+					deleted_at = NULL
 				WHERE
-				    id = $1
+				    id = $1 AND deleted_at IS NOT NULL
 	`
 	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
 
@@ -114,7 +479,27 @@ func (r *operationRepo) Delete(ctx context.Context, uuid string) error {
 	}
 
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("no rows were deleted")
+		return apperror.ErrNotFound
 	}
 	return nil
 }
+
+// Purge removes operations deleted before the given time and returns their number.
+func (r *operationRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `
+				DELETE FROM
+					operations
+				WHERE
+					deleted_at < $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, deletedBefore)
+	if err != nil {
+		return 0, handleSQLError(err, r.logger)
+	}
+	return cmdTag.RowsAffected(), nil
+}
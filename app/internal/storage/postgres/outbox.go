@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+	"time"
+)
+
+type outboxRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewOutboxRepo(client postgresql.Client, logger *logging.Logger) service.OutboxRepo {
+	return &outboxRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *outboxRepo) Save(ctx context.Context, event entity.OutboxEvent) error {
+	query := `
+				INSERT INTO outbox_events
+					(event, aggregate_id, payload)
+				VALUES
+					($1, $2, $3)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	_, err := r.client.Exec(nCtx, query, event.Event, event.AggregateUUID, string(event.Payload))
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+	return nil
+}
+
+// Claim marks the oldest unpublished events, which are not claimed by another relay, as claimed for
+// timeout and returns them. The claim is committed at once, so the events are published without
+// holding their row locks.
+func (r *outboxRepo) Claim(ctx context.Context, limit int, timeout time.Duration) ([]entity.OutboxEvent, error) {
+	query := `
+				WITH claimed AS (
+					UPDATE
+						outbox_events
+					SET
+						claimed_until = now() + make_interval(secs => $2)
+					WHERE
+						id IN (
+							SELECT
+								id
+							FROM
+								outbox_events
+							WHERE
+								published_at IS NULL
+								AND (claimed_until IS NULL OR claimed_until < now())
+							ORDER BY
+								created_at, id
+							LIMIT $1
+							FOR UPDATE SKIP LOCKED
+						)
+					RETURNING
+						id, event, aggregate_id, payload, created_at
+				)
+				SELECT
+					id, event, aggregate_id, payload, created_at
+				FROM
+					claimed
+				ORDER BY
+					created_at, id
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, limit, timeout.Seconds())
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	events := make([]entity.OutboxEvent, 0)
+	for rows.Next() {
+		var event entity.OutboxEvent
+		var payload []byte
+		err = rows.Scan(&event.UUID, &event.Event, &event.AggregateUUID, &payload, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return events, nil
+}
+
+func (r *outboxRepo) MarkPublished(ctx context.Context, uuids []string) error {
+	query := `
+				UPDATE
+					outbox_events
+				SET
+					published_at = now()
+				WHERE
+					id = ANY($1::uuid[])
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	_, err := r.client.Exec(nCtx, query, uuids)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+	return nil
+}
+
+// Release drops the claim of the events, so they are claimed again by the next relay run.
+func (r *outboxRepo) Release(ctx context.Context, uuids []string) error {
+	query := `
+				UPDATE
+					outbox_events
+				SET
+					claimed_until = NULL
+				WHERE
+					id = ANY($1::uuid[])
+					AND published_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	_, err := r.client.Exec(nCtx, query, uuids)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+	"time"
+)
+
+const recurringOperationColumns = `
+					r.id, c.user_id, r.category_id, COALESCE(r.account_id::text, ''), r.money_sum,
+					COALESCE(r.currency, ''), r.description, r.frequency, r.repeat_interval, r.start_date,
+					r.end_date, r.repeat_count, r.occurrences, r.next_date, r.paused, r.failures`
+
+type recurringOperationRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewRecurringOperationRepo(client postgresql.Client, logger *logging.Logger) service.RecurringOperationRepo {
+	return &recurringOperationRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *recurringOperationRepo) Create(ctx context.Context, recurring entity.RecurringOperation) (string, error) {
+	query := `
+				INSERT INTO recurring_operations
+					(category_id, account_id, money_sum, currency, description, frequency, repeat_interval,
+					start_date, end_date, repeat_count, next_date)
+				VALUES
+					($1, NULLIF($2, '')::uuid, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11)
+				RETURNING id;
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var recurringUUID string
+	err := r.client.QueryRow(nCtx, query, recurring.CategoryUUID, recurring.AccountUUID, recurring.MoneySum,
+		recurring.Currency, recurring.Description, recurring.Frequency, recurring.Interval, recurring.StartDate,
+		recurring.EndDate, recurring.Count, recurring.NextDate).Scan(&recurringUUID)
+	if err != nil {
+		return "", handleSQLError(err, r.logger)
+	}
+
+	return recurringUUID, nil
+}
+
+func (r *recurringOperationRepo) FindByUUID(ctx context.Context, uuid string) (entity.RecurringOperation, error) {
+	query := fmt.Sprintf(`
+				SELECT %s
+				FROM
+					recurring_operations r
+				JOIN
+					categories c ON c.id = r.category_id
+				WHERE
+					r.id = $1 AND c.deleted_at IS NULL
+	`, recurringOperationColumns)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	recurring, err := scanRecurringOperation(r.client.QueryRow(nCtx, query, uuid))
+	if err != nil {
+		return entity.RecurringOperation{}, handleSQLError(err, r.logger)
+	}
+
+	return recurring, nil
+}
+
+// FindDueForUpdate returns unpaused recurring operation which next occurrence is not after now
+// and locks it until the end of the transaction carried by ctx.
+func (r *recurringOperationRepo) FindDueForUpdate(ctx context.Context, uuid string,
+	now time.Time) (entity.RecurringOperation, error) {
+	query := fmt.Sprintf(`
+				SELECT %s
+				FROM
+					recurring_operations r
+				JOIN
+					categories c ON c.id = r.category_id
+				WHERE
+					r.id = $1 AND NOT r.paused AND r.next_date <= $2 AND c.deleted_at IS NULL
+				FOR UPDATE OF r
+	`, recurringOperationColumns)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	recurring, err := scanRecurringOperation(r.client.QueryRow(nCtx, query, uuid, now))
+	if err != nil {
+		return entity.RecurringOperation{}, handleSQLError(err, r.logger)
+	}
+
+	return recurring, nil
+}
+
+func (r *recurringOperationRepo) FindByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) ([]entity.RecurringOperation, error) {
+	var where whereClause
+	where.add("c.user_id = $%d", uuid)
+	where.add("c.deleted_at IS NULL")
+	if page.After != nil {
+		where.add("r.id > $%d", page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT %s
+				FROM
+					recurring_operations r
+				JOIN
+					categories c ON c.id = r.category_id
+				%s
+				ORDER BY
+					r.id
+				LIMIT $%d
+	`, recurringOperationColumns, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	recurringOperations := make([]entity.RecurringOperation, 0)
+	for rows.Next() {
+		recurring, err := scanRecurringOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+		recurringOperations = append(recurringOperations, recurring)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return recurringOperations, nil
+}
+
+// FindDue returns uuids of unpaused recurring operations which next occurrence is not after now.
+func (r *recurringOperationRepo) FindDue(ctx context.Context, now time.Time) ([]string, error) {
+	query := `
+				SELECT
+					r.id
+				FROM
+					recurring_operations r
+				JOIN
+					categories c ON c.id = r.category_id
+				WHERE
+					NOT r.paused AND r.next_date <= $1 AND c.deleted_at IS NULL
+				ORDER BY
+					r.next_date
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, now)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	uuids := make([]string, 0)
+	for rows.Next() {
+		var uuid string
+		if err = rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		uuids = append(uuids, uuid)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return uuids, nil
+}
+
+// UpdateSchedule saves the progress of the schedule, whether it is paused and its failures.
+func (r *recurringOperationRepo) UpdateSchedule(ctx context.Context, recurring entity.RecurringOperation) error {
+	query := `
+				UPDATE
+					recurring_operations
+				SET
+					occurrences = $1, next_date = $2, paused = $3, failures = $4
+				WHERE
+					id = $5
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, recurring.Occurrences, recurring.NextDate, recurring.Paused,
+		recurring.Failures, recurring.UUID)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+func (r *recurringOperationRepo) Delete(ctx context.Context, uuid string) error {
+	query := `
+				DELETE FROM
+					recurring_operations
+				WHERE
+					id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecurringOperation(row rowScanner) (entity.RecurringOperation, error) {
+	var recurring entity.RecurringOperation
+	err := row.Scan(&recurring.UUID, &recurring.UserUUID, &recurring.CategoryUUID, &recurring.AccountUUID,
+		&recurring.MoneySum, &recurring.Currency, &recurring.Description, &recurring.Frequency, &recurring.Interval,
+		&recurring.StartDate, &recurring.EndDate, &recurring.Count, &recurring.Occurrences, &recurring.NextDate,
+		&recurring.Paused, &recurring.Failures)
+	return recurring, err
+}
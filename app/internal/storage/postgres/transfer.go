@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+	"time"
+)
+
+type transferRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewTransferRepo(client postgresql.Client, logger *logging.Logger) service.TransferRepo {
+	return &transferRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *transferRepo) Create(ctx context.Context, transfer entity.Transfer) (string, error) {
+	query := `
+				INSERT INTO transfers
+					(user_id, from_account_id, to_account_id, money_sum, currency, description, date_time)
+				VALUES
+					($1, $2, $3, $4, $5, $6, $7)
+				RETURNING id;
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var transferUUID string
+	err := r.client.QueryRow(nCtx, query, transfer.UserUUID, transfer.FromAccountUUID, transfer.ToAccountUUID,
+		transfer.MoneySum, transfer.Currency, transfer.Description, transfer.DateTime).Scan(&transferUUID)
+	if err != nil {
+		return "", handleSQLError(err, r.logger)
+	}
+
+	return transferUUID, nil
+}
+
+func (r *transferRepo) FindByUUID(ctx context.Context, uuid string) (entity.Transfer, error) {
+	query := `
+				SELECT
+					id, user_id, from_account_id, to_account_id, money_sum, currency, description, date_time
+				FROM
+					transfers
+				WHERE
+					id = $1 AND deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var transfer entity.Transfer
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&transfer.UUID, &transfer.UserUUID, &transfer.FromAccountUUID,
+		&transfer.ToAccountUUID, &transfer.MoneySum, &transfer.Currency, &transfer.Description, &transfer.DateTime)
+	if err != nil {
+		return entity.Transfer{}, handleSQLError(err, r.logger)
+	}
+
+	return transfer, nil
+}
+
+func (r *transferRepo) Update(ctx context.Context, transfer entity.Transfer) error {
+	query := `
+				UPDATE
+					transfers
+				SET
+					from_account_id = $1, to_account_id = $2, money_sum = $3, currency = $4, description = $5,
+					date_time = $6
+				WHERE
+					id = $7 AND deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, transfer.FromAccountUUID, transfer.ToAccountUUID, transfer.MoneySum,
+		transfer.Currency, transfer.Description, transfer.DateTime, transfer.UUID)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+// Delete marks the transfer and its operations deleted at the same time, which tells them apart
+// on restore, and returns the operations as they were before deletion.
+func (r *transferRepo) Delete(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	query := `
+				UPDATE
+					transfers
+				SET
+					deleted_at = now()
+				WHERE
+					id = $1 AND deleted_at IS NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return nil, apperror.ErrNotFound
+	}
+
+	query = `
+				UPDATE
+					operations
+				SET
+					deleted_at = now()
+				WHERE
+					transfer_id = $1 AND deleted_at IS NULL
+				RETURNING
+					id, account_id, transfer_id, money_sum, currency, description, date_time
+	`
+	return r.updateOperations(ctx, query, uuid)
+}
+
+func (r *transferRepo) FindDeletedByUUID(ctx context.Context, uuid string) (entity.Transfer, error) {
+	query := `
+				SELECT
+					id, user_id, from_account_id, to_account_id, money_sum, currency, description, date_time
+				FROM
+					transfers
+				WHERE
+					id = $1 AND deleted_at IS NOT NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var transfer entity.Transfer
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&transfer.UUID, &transfer.UserUUID, &transfer.FromAccountUUID,
+		&transfer.ToAccountUUID, &transfer.MoneySum, &transfer.Currency, &transfer.Description, &transfer.DateTime)
+	if err != nil {
+		return entity.Transfer{}, handleSQLError(err, r.logger)
+	}
+
+	return transfer, nil
+}
+
+// Restore restores the transfer together with the operations deleted with it and returns
+// the restored operations.
+func (r *transferRepo) Restore(ctx context.Context, uuid string) ([]entity.Operation, error) {
+	query := `
+				UPDATE
+					operations o
+				SET
+					deleted_at = NULL
+				FROM
+					transfers t
+				WHERE
+					t.id = $1 AND o.transfer_id = t.id AND o.deleted_at = t.deleted_at
+				RETURNING
+					o.id, o.account_id, o.transfer_id, o.money_sum, o.currency, o.description, o.date_time
+	`
+	operations, err := r.updateOperations(ctx, query, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+				UPDATE
+					transfers
+				SET
+					deleted_at = NULL
+				WHERE
+					id = $1 AND deleted_at IS NOT NULL
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return nil, apperror.ErrNotFound
+	}
+	return operations, nil
+}
+
+// Purge removes transfers deleted before the given time and returns their number. Transfers
+// still referenced by operations are left to the next purge, after those are removed.
+func (r *transferRepo) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	query := `
+				DELETE FROM
+					transfers t
+				WHERE
+					t.deleted_at < $1
+					AND NOT EXISTS (SELECT 1 FROM operations WHERE transfer_id = t.id)
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, deletedBefore)
+	if err != nil {
+		return 0, handleSQLError(err, r.logger)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// updateOperations runs the query updating operations of a transfer and scans the operations it returns.
+func (r *transferRepo) updateOperations(ctx context.Context, query string, args ...any) ([]entity.Operation, error) {
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	operations := make([]entity.Operation, 0)
+	for rows.Next() {
+		var operation entity.Operation
+		err = rows.Scan(&operation.UUID, &operation.AccountUUID, &operation.TransferUUID, &operation.MoneySum,
+			&operation.Currency, &operation.Description, &operation.DateTime)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, operation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	return operations, nil
+}
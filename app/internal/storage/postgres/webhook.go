@@ -0,0 +1,327 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/entity"
+	"operation-service/internal/domain/service"
+	"operation-service/internal/domain/types"
+	"operation-service/pkg/logging"
+	"operation-service/pkg/pagination"
+	"operation-service/pkg/postgresql"
+	"operation-service/pkg/utils"
+	"time"
+)
+
+const webhookDeliveryColumns = `
+					d.id, d.webhook_id, d.event, d.payload, d.status, d.attempts, d.next_attempt_at,
+					d.response_status, COALESCE(d.last_error, ''), d.created_at, d.delivered_at`
+
+type webhookRepo struct {
+	client postgresql.Client
+	logger *logging.Logger
+}
+
+func NewWebhookRepo(client postgresql.Client, logger *logging.Logger) service.WebhookRepo {
+	return &webhookRepo{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *webhookRepo) Create(ctx context.Context, webhook entity.Webhook) (string, error) {
+	query := `
+				INSERT INTO webhooks
+					(user_id, url, secret)
+				VALUES
+					($1, $2, $3)
+				RETURNING id;
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var webhookUUID string
+	err := r.client.QueryRow(nCtx, query, webhook.UserUUID, webhook.URL, webhook.Secret).Scan(&webhookUUID)
+	if err != nil {
+		return "", handleSQLError(err, r.logger)
+	}
+
+	return webhookUUID, nil
+}
+
+func (r *webhookRepo) FindByUUID(ctx context.Context, uuid string) (entity.Webhook, error) {
+	query := `
+				SELECT
+					id, user_id, url, secret, created_at
+				FROM
+					webhooks
+				WHERE
+					id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var webhook entity.Webhook
+	err := r.client.QueryRow(nCtx, query, uuid).Scan(&webhook.UUID, &webhook.UserUUID, &webhook.URL, &webhook.Secret,
+		&webhook.CreatedAt)
+	if err != nil {
+		return entity.Webhook{}, handleSQLError(err, r.logger)
+	}
+
+	return webhook, nil
+}
+
+func (r *webhookRepo) FindByUserUUID(ctx context.Context, uuid string,
+	page pagination.Params) ([]entity.Webhook, error) {
+	var where whereClause
+	where.add("user_id = $%d", uuid)
+	if page.After != nil {
+		where.add("id > $%d", page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT
+					id, user_id, url, secret, created_at
+				FROM
+					webhooks
+				%s
+				ORDER BY
+					id
+				LIMIT $%d
+	`, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	webhooks := make([]entity.Webhook, 0)
+	for rows.Next() {
+		var webhook entity.Webhook
+		err = rows.Scan(&webhook.UUID, &webhook.UserUUID, &webhook.URL, &webhook.Secret, &webhook.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return webhooks, nil
+}
+
+func (r *webhookRepo) Delete(ctx context.Context, uuid string) error {
+	query := `
+				DELETE FROM
+					webhooks
+				WHERE
+					id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, uuid)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+// CreateDeliveries queues the event to every webhook of the user.
+func (r *webhookRepo) CreateDeliveries(ctx context.Context, userUUID, event string, payload []byte) error {
+	query := `
+				INSERT INTO webhook_deliveries
+					(webhook_id, event, payload)
+				SELECT
+					id, $2, $3
+				FROM
+					webhooks
+				WHERE
+					user_id = $1
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	_, err := r.client.Exec(nCtx, query, userUUID, event, string(payload))
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+	return nil
+}
+
+func (r *webhookRepo) FindDeliveries(ctx context.Context, webhookUUID string,
+	page pagination.Params) ([]entity.WebhookDelivery, error) {
+	var where whereClause
+	where.add("d.webhook_id = $%d", webhookUUID)
+	if page.After != nil {
+		where.add("(d.created_at, d.id) < ($%d, $%d::uuid)", *page.After.DateTime, page.After.UUID)
+	}
+	limit := where.param(page.Limit + 1)
+
+	query := fmt.Sprintf(`
+				SELECT %s
+				FROM
+					webhook_deliveries d
+				%s
+				ORDER BY
+					d.created_at DESC, d.id DESC
+				LIMIT $%d
+	`, webhookDeliveryColumns, where.String(), limit)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, where.args...)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		if err = scanWebhookDelivery(rows, &delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return deliveries, nil
+}
+
+// FindDueDeliveries returns uuids of pending deliveries which next attempt is not after now.
+func (r *webhookRepo) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	query := `
+				SELECT
+					id
+				FROM
+					webhook_deliveries
+				WHERE
+					status = $1 AND next_attempt_at <= $2
+				ORDER BY
+					next_attempt_at
+				LIMIT $3
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	rows, err := r.client.Query(nCtx, query, types.PendingDelivery, now, limit)
+	if err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+	defer rows.Close()
+
+	uuids := make([]string, 0)
+	for rows.Next() {
+		var uuid string
+		if err = rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+		uuids = append(uuids, uuid)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, handleSQLError(err, r.logger)
+	}
+
+	return uuids, nil
+}
+
+// FindDueDeliveryForUpdate returns the pending delivery with URL and secret of its webhook if its
+// next attempt is not after now and locks it until the end of the transaction carried by ctx.
+// Deliveries locked by another transaction are skipped.
+func (r *webhookRepo) FindDueDeliveryForUpdate(ctx context.Context, uuid string,
+	now time.Time) (entity.WebhookDelivery, error) {
+	query := fmt.Sprintf(`
+				SELECT %s, w.url, w.secret
+				FROM
+					webhook_deliveries d
+				JOIN
+					webhooks w ON w.id = d.webhook_id
+				WHERE
+					d.id = $1 AND d.status = $2 AND d.next_attempt_at <= $3
+				FOR UPDATE OF d SKIP LOCKED
+	`, webhookDeliveryColumns)
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	var delivery entity.WebhookDelivery
+	err := scanWebhookDelivery(r.client.QueryRow(nCtx, query, uuid, types.PendingDelivery, now), &delivery,
+		&delivery.URL, &delivery.Secret)
+	if err != nil {
+		return entity.WebhookDelivery{}, handleSQLError(err, r.logger)
+	}
+
+	return delivery, nil
+}
+
+// UpdateDelivery saves the result of a delivery attempt.
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	query := `
+				UPDATE
+					webhook_deliveries
+				SET
+					status = $1, attempts = $2, next_attempt_at = $3, response_status = $4,
+					last_error = NULLIF($5, ''), delivered_at = $6
+				WHERE
+					id = $7
+	`
+	r.logger.Trace(fmt.Sprintf("SQL query: %s", utils.FormatSQLQuery(query)))
+
+	nCtx, cancel := context.WithTimeout(ctx, queryWaitTime)
+	defer cancel()
+
+	cmdTag, err := r.client.Exec(nCtx, query, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt, delivery.UUID)
+	if err != nil {
+		return handleSQLError(err, r.logger)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return apperror.ErrNotFound
+	}
+	return nil
+}
+
+// scanWebhookDelivery scans webhookDeliveryColumns followed by extra columns into delivery.
+func scanWebhookDelivery(row rowScanner, delivery *entity.WebhookDelivery, extra ...interface{}) error {
+	var payload []byte
+	dest := []interface{}{&delivery.UUID, &delivery.WebhookUUID, &delivery.Event, &payload, &delivery.Status,
+		&delivery.Attempts, &delivery.NextAttemptAt, &delivery.ResponseStatus, &delivery.LastError,
+		&delivery.CreatedAt, &delivery.DeliveredAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+	delivery.Payload = payload
+	return nil
+}
@@ -0,0 +1,194 @@
+// Package validation checks fields of request DTOs. Every DTO has a Validate method, handlers call
+// it before the DTO is passed to a service, so services get only well-formed data and check it
+// against the stored state.
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"operation-service/internal/apperror"
+	"operation-service/internal/domain/types"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Maximal lengths of strings kept in VARCHAR columns.
+const (
+	NameMaxLength        = 100
+	DescriptionMaxLength = 255
+	SecretMaxLength      = 255
+	URLMaxLength         = 2048
+)
+
+const (
+	dateLayout        = "2006-01-02"
+	maxFutureDateTime = 24 * time.Hour
+)
+
+var (
+	// MaxMoney is the greatest absolute amount fitting NUMERIC(15, 2) columns.
+	MaxMoney = types.MoneyFromMinor(999_999_999_999_999)
+
+	minDateTime = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Optional checks below skip empty values, Required checks report them.
+
+func Required(errs *apperror.FieldErrors, field, value string) bool {
+	if value == "" {
+		errs.Add(field, apperror.CodeRequired, fmt.Sprintf("%s must be specified", field))
+		return false
+	}
+	return true
+}
+
+func UUID(errs *apperror.FieldErrors, field, value string) {
+	if value != "" && !IsUUID(value) {
+		errs.Add(field, apperror.CodeInvalid, fmt.Sprintf("%s must be uuid", field))
+	}
+}
+
+func RequiredUUID(errs *apperror.FieldErrors, field, value string) {
+	if Required(errs, field, value) {
+		UUID(errs, field, value)
+	}
+}
+
+// MaxLength limits the number of characters as VARCHAR(max) does.
+func MaxLength(errs *apperror.FieldErrors, field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must be at most %d characters long", field, max))
+	}
+}
+
+func MinLength(errs *apperror.FieldErrors, field, value string, min int) {
+	if value != "" && utf8.RuneCountInString(value) < min {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must be at least %d characters long", field, min))
+	}
+}
+
+func Currency(errs *apperror.FieldErrors, field string, currency types.Currency) {
+	if currency != "" && !currency.IsValid() {
+		errs.Add(field, apperror.CodeInvalid, fmt.Sprintf("%s must be ISO 4217 code", field))
+	}
+}
+
+func RequiredCurrency(errs *apperror.FieldErrors, field string, currency types.Currency) {
+	if Required(errs, field, string(currency)) {
+		Currency(errs, field, currency)
+	}
+}
+
+func OneOf[T ~string](errs *apperror.FieldErrors, field string, value T, allowed ...T) {
+	if value == "" {
+		return
+	}
+	names := make([]string, 0, len(allowed))
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+		names = append(names, string(a))
+	}
+	errs.Add(field, apperror.CodeInvalid, fmt.Sprintf("%s must be one of %s", field, strings.Join(names, ", ")))
+}
+
+// PositiveMoney requires the amount to be greater than zero and to fit the database.
+func PositiveMoney(errs *apperror.FieldErrors, field string, money types.Money) {
+	if !money.IsPositive() {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must be positive", field))
+		return
+	}
+	maxMoney(errs, field, money)
+}
+
+// NonNegativeMoney is PositiveMoney for amounts where zero means that the amount is not set.
+func NonNegativeMoney(errs *apperror.FieldErrors, field string, money types.Money) {
+	if money.IsNegative() {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s can not be negative", field))
+		return
+	}
+	maxMoney(errs, field, money)
+}
+
+func maxMoney(errs *apperror.FieldErrors, field string, money types.Money) {
+	if money.Cmp(MaxMoney) > 0 {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must not exceed %s", field, MaxMoney))
+	}
+}
+
+// DateTime rejects dates before 1900 and more than a day ahead of now.
+// A day of tolerance allows for clients in time zones ahead of the server.
+func DateTime(errs *apperror.FieldErrors, field string, dateTime *time.Time) {
+	if dateTime == nil {
+		return
+	}
+	if dateTime.Before(minDateTime) {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must not be before 1900-01-01", field))
+	}
+	if dateTime.After(time.Now().Add(maxFutureDateTime)) {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must not be in the future", field))
+	}
+}
+
+// MinDate rejects dates before 1900, dates of schedules and reports may be in the future.
+func MinDate(errs *apperror.FieldErrors, field string, date *time.Time) {
+	if date != nil && date.Before(minDateTime) {
+		errs.Add(field, apperror.CodeOutOfRange, fmt.Sprintf("%s must not be before 1900-01-01", field))
+	}
+}
+
+// RequiredDate requires date in YYYY-MM-DD format.
+func RequiredDate(errs *apperror.FieldErrors, field, value string) {
+	if !Required(errs, field, value) {
+		return
+	}
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		errs.Add(field, apperror.CodeInvalid, fmt.Sprintf("%s must be in YYYY-MM-DD format", field))
+	}
+}
+
+// DateRange reports the end of the range if it is before the start.
+func DateRange(errs *apperror.FieldErrors, fromField, toField string, from, to *time.Time) {
+	if from != nil && to != nil && to.Before(*from) {
+		errs.Add(toField, apperror.CodeOutOfRange, fmt.Sprintf("%s must not be before %s", toField, fromField))
+	}
+}
+
+// HTTPURL requires absolute http or https url.
+func HTTPURL(errs *apperror.FieldErrors, field, value string) {
+	if value == "" {
+		return
+	}
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs.Add(field, apperror.CodeInvalid, fmt.Sprintf("%s must be absolute http or https url", field))
+		return
+	}
+	MaxLength(errs, field, value, URLMaxLength)
+}
+
+// IsUUID checks the canonical textual form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx.
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
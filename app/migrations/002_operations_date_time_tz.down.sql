@@ -0,0 +1,2 @@
+ALTER TABLE public.operations
+    ALTER COLUMN date_time TYPE TIMESTAMP WITHOUT TIME ZONE USING date_time AT TIME ZONE 'UTC';
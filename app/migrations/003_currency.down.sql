@@ -0,0 +1,5 @@
+ALTER TABLE public.operations
+    DROP COLUMN currency;
+
+ALTER TABLE public.categories
+    DROP COLUMN currency;
@@ -0,0 +1,9 @@
+ALTER TABLE public.categories
+    ADD COLUMN currency CHAR(3);
+
+-- operations created before multi-currency support are considered to be in USD
+ALTER TABLE public.operations
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE public.operations
+    ALTER COLUMN currency DROP DEFAULT;
@@ -0,0 +1,8 @@
+CREATE TABLE public.exchange_rates
+(
+    base  CHAR(3)        NOT NULL,
+    quote CHAR(3)        NOT NULL,
+    date  DATE           NOT NULL,
+    rate  NUMERIC(20, 10) NOT NULL CHECK (rate > 0),
+    PRIMARY KEY (base, quote, date)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE public.operations
+    DROP COLUMN account_id;
+
+DROP TABLE public.accounts;
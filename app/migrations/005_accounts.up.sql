@@ -0,0 +1,11 @@
+CREATE TABLE public.accounts
+(
+    id       UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id  UUID         NOT NULL,
+    name     VARCHAR(100) NOT NULL,
+    currency CHAR(3)      NOT NULL
+);
+
+ALTER TABLE public.operations
+    ADD COLUMN account_id UUID,
+    ADD CONSTRAINT account_fk FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT;
@@ -0,0 +1,10 @@
+-- operations of transfers have no category and can not be kept
+DELETE FROM public.operations WHERE transfer_id IS NOT NULL;
+
+ALTER TABLE public.operations
+    DROP CONSTRAINT transfer_has_account,
+    DROP CONSTRAINT category_or_transfer,
+    DROP COLUMN transfer_id,
+    ALTER COLUMN category_id SET NOT NULL;
+
+DROP TABLE public.transfers;
@@ -0,0 +1,22 @@
+CREATE TABLE public.transfers
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id         UUID           NOT NULL,
+    from_account_id UUID           NOT NULL,
+    to_account_id   UUID           NOT NULL,
+    money_sum       NUMERIC(15, 2) NOT NULL CHECK (money_sum > 0),
+    currency        CHAR(3)        NOT NULL,
+    description     VARCHAR(255),
+    date_time       TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT from_account_fk FOREIGN KEY (from_account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT to_account_fk FOREIGN KEY (to_account_id) REFERENCES accounts (id) ON DELETE RESTRICT,
+    CONSTRAINT different_accounts CHECK (from_account_id <> to_account_id)
+);
+
+-- a transfer is stored as a debit and a credit operation on its accounts, which have no category
+ALTER TABLE public.operations
+    ALTER COLUMN category_id DROP NOT NULL,
+    ADD COLUMN transfer_id UUID,
+    ADD CONSTRAINT transfer_fk FOREIGN KEY (transfer_id) REFERENCES transfers (id) ON DELETE CASCADE,
+    ADD CONSTRAINT category_or_transfer CHECK ((category_id IS NULL) <> (transfer_id IS NULL)),
+    ADD CONSTRAINT transfer_has_account CHECK (transfer_id IS NULL OR account_id IS NOT NULL);
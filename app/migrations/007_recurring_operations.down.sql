@@ -0,0 +1 @@
+DROP TABLE public.recurring_operations;
@@ -0,0 +1,23 @@
+CREATE TABLE public.recurring_operations
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id     UUID           NOT NULL,
+    account_id      UUID,
+    money_sum       NUMERIC(15, 2) NOT NULL CHECK (money_sum > 0),
+    currency        CHAR(3),
+    description     VARCHAR(255),
+    frequency       VARCHAR(10)    NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    repeat_interval INTEGER        NOT NULL DEFAULT 1 CHECK (repeat_interval > 0),
+    start_date      TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date        TIMESTAMP WITH TIME ZONE,
+    -- zero means the number of occurrences is not limited
+    repeat_count    INTEGER        NOT NULL DEFAULT 0 CHECK (repeat_count >= 0),
+    occurrences     INTEGER        NOT NULL DEFAULT 0,
+    -- NULL once the schedule is over
+    next_date       TIMESTAMP WITH TIME ZONE,
+    paused          BOOLEAN        NOT NULL DEFAULT FALSE,
+    CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE,
+    CONSTRAINT account_fk FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX recurring_operations_next_date_idx ON public.recurring_operations (next_date) WHERE NOT paused;
@@ -0,0 +1,11 @@
+CREATE TABLE public.budgets
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID           NOT NULL,
+    period      VARCHAR(10)    NOT NULL CHECK (period IN ('week', 'month', 'year')),
+    money_limit NUMERIC(15, 2) NOT NULL CHECK (money_limit > 0),
+    currency    CHAR(3)        NOT NULL,
+    rollover    BOOLEAN        NOT NULL DEFAULT FALSE,
+    CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE,
+    CONSTRAINT budget_period_unique UNIQUE (category_id, period)
+);
@@ -0,0 +1,3 @@
+DROP TABLE public.budget_alerts;
+DROP TABLE public.webhook_deliveries;
+DROP TABLE public.webhooks;
@@ -0,0 +1,42 @@
+CREATE TABLE public.webhooks
+(
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id    UUID          NOT NULL,
+    url        VARCHAR(2048) NOT NULL,
+    secret     VARCHAR(255)  NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhooks_user_id_idx ON public.webhooks (user_id);
+
+CREATE TABLE public.webhook_deliveries
+(
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id      UUID         NOT NULL,
+    event           VARCHAR(100) NOT NULL,
+    payload         JSONB        NOT NULL,
+    status          VARCHAR(10)  NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    -- NULL once the delivery is delivered or failed
+    next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    response_status INTEGER,
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    delivered_at    TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT webhook_fk FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_next_attempt_at_idx ON public.webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_webhook_id_idx ON public.webhook_deliveries (webhook_id, created_at DESC, id DESC);
+
+-- thresholds of budgets already reached in a period, an alert is sent once per threshold and period
+CREATE TABLE public.budget_alerts
+(
+    budget_id    UUID    NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    threshold    INTEGER NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (budget_id, period_start, threshold),
+    CONSTRAINT budget_fk FOREIGN KEY (budget_id) REFERENCES budgets (id) ON DELETE CASCADE
+);
@@ -0,0 +1,12 @@
+-- domain events written in the same transaction as the changes they describe and relayed to publisher
+CREATE TABLE public.outbox_events
+(
+    id             UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event          VARCHAR(100) NOT NULL,
+    aggregate_id   UUID         NOT NULL,
+    payload        JSONB        NOT NULL,
+    created_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT clock_timestamp(),
+    published_at   TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX outbox_events_unpublished_idx ON public.outbox_events (created_at) WHERE published_at IS NULL;
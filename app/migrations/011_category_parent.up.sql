@@ -0,0 +1,8 @@
+-- a category may be nested into a category of the same user and type, a category with
+-- subcategories can not be deleted
+ALTER TABLE public.categories
+    ADD COLUMN parent_id UUID,
+    ADD CONSTRAINT parent_fk FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE RESTRICT,
+    ADD CONSTRAINT parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX categories_parent_id_idx ON public.categories (parent_id);
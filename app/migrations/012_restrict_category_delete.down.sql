@@ -0,0 +1,3 @@
+ALTER TABLE public.operations
+    DROP CONSTRAINT category_fk,
+    ADD CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE;
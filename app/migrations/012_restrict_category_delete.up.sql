@@ -0,0 +1,5 @@
+-- operations are no longer deleted together with their category, the service moves them to
+-- another category or deletes them explicitly
+ALTER TABLE public.operations
+    DROP CONSTRAINT category_fk,
+    ADD CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE RESTRICT;
@@ -0,0 +1,11 @@
+-- deleted rows would reappear without the column
+DELETE FROM public.operations WHERE deleted_at IS NOT NULL;
+DELETE FROM public.operations o USING public.categories c WHERE o.category_id = c.id AND c.deleted_at IS NOT NULL;
+UPDATE public.categories SET parent_id = NULL WHERE deleted_at IS NOT NULL;
+DELETE FROM public.categories WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE public.operations
+    DROP COLUMN deleted_at;
+
+ALTER TABLE public.categories
+    DROP COLUMN deleted_at;
@@ -0,0 +1,9 @@
+-- deleted operations and categories are kept until purged after the retention period
+ALTER TABLE public.operations
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+ALTER TABLE public.categories
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX operations_deleted_at_idx ON public.operations (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX categories_deleted_at_idx ON public.categories (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,27 @@
+-- append-only log of changes of operations and categories
+CREATE TABLE public.audit_log
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(50)  NOT NULL,
+    entity_id   UUID         NOT NULL,
+    action      VARCHAR(50)  NOT NULL,
+    actor       VARCHAR(255) NOT NULL DEFAULT '',
+    request_id  VARCHAR(255) NOT NULL DEFAULT '',
+    changes     JSONB        NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT clock_timestamp()
+);
+
+CREATE INDEX audit_log_entity_idx ON public.audit_log (entity_type, entity_id, created_at DESC, id DESC);
+
+CREATE FUNCTION audit_log_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE
+    ON public.audit_log
+    FOR EACH ROW
+EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,7 @@
+DROP INDEX public.categories_user_name_type_key;
+
+ALTER TABLE public.categories
+    DROP CONSTRAINT categories_type_check;
+
+DROP INDEX public.operations_category_id_date_time_idx;
+DROP INDEX public.categories_user_id_idx;
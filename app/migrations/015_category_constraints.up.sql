@@ -0,0 +1,11 @@
+-- indexes of the lookups by user and of operations of a category ordered by date, category names
+-- are unique per user and type among categories which are not deleted
+CREATE INDEX categories_user_id_idx ON public.categories (user_id);
+CREATE INDEX operations_category_id_date_time_idx ON public.operations (category_id, date_time);
+
+ALTER TABLE public.categories
+    ADD CONSTRAINT categories_type_check CHECK (type IN ('Income', 'Expense'));
+
+-- fails if the users already have duplicate categories, which must be renamed first
+CREATE UNIQUE INDEX categories_user_name_type_key ON public.categories (user_id, name, type)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE public.recurring_operations
+    DROP COLUMN failures;
@@ -0,0 +1,3 @@
+-- consecutive failures to create operations of a recurring operation, it is paused after too many
+ALTER TABLE public.recurring_operations
+    ADD COLUMN failures INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE public.outbox_events
+    DROP COLUMN claimed_until;
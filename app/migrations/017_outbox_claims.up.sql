@@ -0,0 +1,4 @@
+-- events are claimed by a relay for a while and published outside of the claiming transaction,
+-- an event whose claim has expired is claimed again
+ALTER TABLE public.outbox_events
+    ADD COLUMN claimed_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,9 @@
+ALTER TABLE public.operations
+    DROP CONSTRAINT transfer_fk,
+    ADD CONSTRAINT transfer_fk FOREIGN KEY (transfer_id) REFERENCES transfers (id) ON DELETE CASCADE;
+
+-- deleted transfers would reappear without the column, their operations are removed by cascade
+DELETE FROM public.transfers WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE public.transfers
+    DROP COLUMN deleted_at;
@@ -0,0 +1,10 @@
+-- deleted transfers are kept with their operations until purged after the retention period,
+-- operations are no longer removed together with their transfer
+ALTER TABLE public.transfers
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX transfers_deleted_at_idx ON public.transfers (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE public.operations
+    DROP CONSTRAINT transfer_fk,
+    ADD CONSTRAINT transfer_fk FOREIGN KEY (transfer_id) REFERENCES transfers (id) ON DELETE RESTRICT;
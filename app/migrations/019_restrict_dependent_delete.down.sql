@@ -0,0 +1,9 @@
+ALTER TABLE public.recurring_operations
+    DROP CONSTRAINT category_fk,
+    ADD CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE,
+    DROP CONSTRAINT account_fk,
+    ADD CONSTRAINT account_fk FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE;
+
+ALTER TABLE public.budgets
+    DROP CONSTRAINT category_fk,
+    ADD CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE;
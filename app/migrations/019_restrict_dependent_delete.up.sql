@@ -0,0 +1,12 @@
+-- recurring operations and budgets are no longer deleted together with their category or account,
+-- accounts used by recurring operations can not be deleted and the purger removes recurring
+-- operations and budgets of purged categories explicitly
+ALTER TABLE public.recurring_operations
+    DROP CONSTRAINT category_fk,
+    ADD CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE RESTRICT,
+    DROP CONSTRAINT account_fk,
+    ADD CONSTRAINT account_fk FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE RESTRICT;
+
+ALTER TABLE public.budgets
+    DROP CONSTRAINT category_fk,
+    ADD CONSTRAINT category_fk FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE RESTRICT;
@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL migrations of the service. A migration is a pair
+// of files NNN_name.up.sql and NNN_name.down.sql, where NNN is its version.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,225 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+var testNow = time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func signHS256(t *testing.T, secret string, h header, claims map[string]any) string {
+	t.Helper()
+	signed := encodeSegment(t, h) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signed := encodeSegment(t, header{Alg: "RS256", Kid: kid}) + "." + encodeSegment(t, claims)
+	hash := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newTestVerifier(secret string, keys map[string]*rsa.PublicKey, issuer, audience string) *Verifier {
+	v := NewVerifier(secret, keys, issuer, audience)
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+func TestVerifyHS256(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{"sub": "user", "exp": testNow.Add(time.Hour).Unix()}
+	}
+	with := func(name string, value any) map[string]any {
+		claims := valid()
+		claims[name] = value
+		return claims
+	}
+	hs256 := header{Alg: "HS256"}
+
+	tests := []struct {
+		name     string
+		token    string
+		issuer   string
+		audience string
+		wantErr  error
+	}{
+		{name: "valid", token: signHS256(t, testSecret, hs256, valid())},
+		{name: "wrong secret", token: signHS256(t, "another secret", hs256, valid()),
+			wantErr: ErrInvalidSignature},
+		{name: "alg none", token: signHS256(t, testSecret, header{Alg: "none"}, valid()),
+			wantErr: ErrUnsupportedAlg},
+		{name: "not three segments", token: "a.b", wantErr: ErrMalformed},
+		{name: "header is not base64", token: "!.b.c", wantErr: ErrMalformed},
+		{name: "without exp", token: signHS256(t, testSecret, hs256, map[string]any{"sub": "user"}),
+			wantErr: ErrInvalidClaims},
+		{name: "expired", token: signHS256(t, testSecret, hs256, with("exp", testNow.Add(-2*time.Minute).Unix())),
+			wantErr: ErrExpired},
+		{name: "expired within leeway",
+			token: signHS256(t, testSecret, hs256, with("exp", testNow.Add(-30*time.Second).Unix()))},
+		{name: "not yet valid", token: signHS256(t, testSecret, hs256, with("nbf", testNow.Add(2*time.Minute).Unix())),
+			wantErr: ErrNotYetValid},
+		{name: "issuer", token: signHS256(t, testSecret, hs256, with("iss", "auth")), issuer: "auth"},
+		{name: "unexpected issuer", token: signHS256(t, testSecret, hs256, with("iss", "other")), issuer: "auth",
+			wantErr: ErrInvalidClaims},
+		{name: "audience string", token: signHS256(t, testSecret, hs256, with("aud", "api")), audience: "api"},
+		{name: "audience array", token: signHS256(t, testSecret, hs256, with("aud", []string{"web", "api"})),
+			audience: "api"},
+		{name: "unexpected audience", token: signHS256(t, testSecret, hs256, with("aud", []string{"web"})),
+			audience: "api", wantErr: ErrInvalidClaims},
+		{name: "missing audience", token: signHS256(t, testSecret, hs256, valid()), audience: "api",
+			wantErr: ErrInvalidClaims},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := newTestVerifier(testSecret, nil, tt.issuer, tt.audience).Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && claims.String("sub") != "user" {
+				t.Errorf("Verify() sub = %q, want %q", claims.String("sub"), "user")
+			}
+		})
+	}
+}
+
+func TestVerifyRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := map[string]any{"sub": "user", "exp": testNow.Add(time.Hour).Unix()}
+
+	tests := []struct {
+		name    string
+		keys    map[string]*rsa.PublicKey
+		secret  string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey}, token: signRS256(t, key, "k1", claims)},
+		{name: "only key without kid", keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey},
+			token: signRS256(t, key, "", claims)},
+		{name: "unknown kid", keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey},
+			token: signRS256(t, key, "k2", claims), wantErr: ErrUnknownKey},
+		{name: "signed by another key", keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey},
+			token: signRS256(t, otherKey, "k1", claims), wantErr: ErrInvalidSignature},
+		{name: "no keys", secret: testSecret, token: signRS256(t, key, "k1", claims), wantErr: ErrUnsupportedAlg},
+		{name: "hs256 without secret", keys: map[string]*rsa.PublicKey{"k1": &key.PublicKey},
+			token: signHS256(t, "", header{Alg: "HS256"}, claims), wantErr: ErrUnsupportedAlg},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestVerifier(tt.secret, tt.keys, "", "").Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1})
+
+	tests := []struct {
+		name     string
+		jwks     string
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "rsa signing key", jwks: `{"keys":[{"kty":"RSA","kid":"k1","use":"sig","n":"` + n + `","e":"` + e + `"}]}`,
+			wantKids: []string{"k1"}},
+		{name: "other keys are skipped", jwks: `{"keys":[{"kty":"EC","kid":"k0"},` +
+			`{"kty":"RSA","kid":"k1","use":"enc","n":"` + n + `","e":"` + e + `"},` +
+			`{"kty":"RSA","kid":"k2","n":"` + n + `","e":"` + e + `"}]}`, wantKids: []string{"k2"}},
+		{name: "no rsa keys", jwks: `{"keys":[{"kty":"EC","kid":"k0"}]}`, wantErr: true},
+		{name: "invalid modulus", jwks: `{"keys":[{"kty":"RSA","kid":"k1","n":"!","e":"` + e + `"}]}`, wantErr: true},
+		{name: "not json", jwks: `keys`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jwks.json")
+			if err := os.WriteFile(path, []byte(tt.jwks), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			keys, err := LoadJWKS(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadJWKS() error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(keys) != len(tt.wantKids) {
+				t.Fatalf("LoadJWKS() returned %d keys, want %d", len(keys), len(tt.wantKids))
+			}
+			for _, kid := range tt.wantKids {
+				if keys[kid] == nil || keys[kid].N.Cmp(key.N) != 0 || keys[kid].E != key.E {
+					t.Errorf("LoadJWKS() key %q does not match", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestClaimsStrings(t *testing.T) {
+	claims := Claims{"role": "admin", "roles": []any{"admin", 1, "user"}, "count": 1.0}
+
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{name: "role", want: []string{"admin"}},
+		{name: "roles", want: []string{"admin", "user"}},
+		{name: "count", want: nil},
+		{name: "missing", want: nil},
+	}
+
+	for _, tt := range tests {
+		got := claims.Strings(tt.name)
+		if len(got) != len(tt.want) {
+			t.Fatalf("Strings(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Strings(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestReadMigrations(t *testing.T) {
+	file := func(sql string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(sql)}
+	}
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr bool
+	}{
+		{
+			name: "pairs are ordered by version",
+			fsys: fstest.MapFS{
+				"010_outbox.up.sql":   file("CREATE TABLE outbox"),
+				"010_outbox.down.sql": file("DROP TABLE outbox"),
+				"002_tz.up.sql":       file("ALTER TABLE a"),
+				"002_tz.down.sql":     file("ALTER TABLE b"),
+				"001_init.up.sql":     file("CREATE TABLE c"),
+				"001_init.down.sql":   file("DROP TABLE c"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "init", Up: "CREATE TABLE c", Down: "DROP TABLE c"},
+				{Version: 2, Name: "tz", Up: "ALTER TABLE a", Down: "ALTER TABLE b"},
+				{Version: 10, Name: "outbox", Up: "CREATE TABLE outbox", Down: "DROP TABLE outbox"},
+			},
+		},
+		{
+			name: "other files and directories are skipped",
+			fsys: fstest.MapFS{
+				"001_init.up.sql":      file("CREATE TABLE c"),
+				"001_init.down.sql":    file("DROP TABLE c"),
+				"migrations.go":        file("package migrations"),
+				"README.md":            file("docs"),
+				"002_draft.sql":        file("SELECT 1"),
+				"003_dir.up.sql/x.sql": file("SELECT 1"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "init", Up: "CREATE TABLE c", Down: "DROP TABLE c"},
+			},
+		},
+		{
+			name: "empty directory",
+			fsys: fstest.MapFS{},
+			want: []Migration{},
+		},
+		{
+			name: "up file without down file",
+			fsys: fstest.MapFS{
+				"001_init.up.sql": file("CREATE TABLE c"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "down file without up file",
+			fsys: fstest.MapFS{
+				"001_init.down.sql": file("DROP TABLE c"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty down file",
+			fsys: fstest.MapFS{
+				"001_init.up.sql":   file("CREATE TABLE c"),
+				"001_init.down.sql": file(""),
+			},
+			wantErr: true,
+		},
+		{
+			name: "same version of different migrations",
+			fsys: fstest.MapFS{
+				"001_init.up.sql":     file("CREATE TABLE c"),
+				"001_init.down.sql":   file("DROP TABLE c"),
+				"001_other.up.sql":    file("CREATE TABLE d"),
+				"001_other.down.sql":  file("DROP TABLE d"),
+				"002_second.up.sql":   file("CREATE TABLE e"),
+				"002_second.down.sql": file("DROP TABLE e"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "version out of range",
+			fsys: fstest.MapFS{
+				"99999999999999999999_init.up.sql":   file("CREATE TABLE c"),
+				"99999999999999999999_init.down.sql": file("DROP TABLE c"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readMigrations(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readMigrations() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("readMigrations() returned %d migrations, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("migration %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+var (
+	ErrInvalidLimit  = fmt.Errorf("limit must be an integer between 1 and %d", MaxLimit)
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Cursor points to the last item of a page. Items are ordered by DateTime (if the
+// resource has one) and UUID, so the next page starts right after the cursor.
+type Cursor struct {
+	DateTime *time.Time `json:"t,omitempty"`
+	UUID     string     `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	bytes, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(bytes, &cursor); err != nil || cursor.UUID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+type Params struct {
+	Limit int
+	After *Cursor
+}
+
+func NewParams(limit, cursor string) (Params, error) {
+	params := Params{Limit: DefaultLimit}
+
+	if limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 || l > MaxLimit {
+			return Params{}, ErrInvalidLimit
+		}
+		params.Limit = l
+	}
+
+	if cursor != "" {
+		after, err := DecodeCursor(cursor)
+		if err != nil {
+			return Params{}, err
+		}
+		params.After = after
+	}
+	return params, nil
+}
+
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPage builds a page from items fetched with limit+1 rows: the extra row only
+// signals that there is a next page and is not returned to the client.
+func NewPage[T any](items []T, limit int, cursor func(T) Cursor) Page[T] {
+	page := Page[T]{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = cursor(items[limit-1]).Encode()
+	}
+	return page
+}
@@ -0,0 +1,66 @@
+package postgresql
+
+import (
+	"context"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type txKey struct{}
+
+// TxClient is a Client that runs queries in the transaction carried by the context, if any.
+// Transactions are started by WithinTransaction and shared through the context, so several
+// repositories built on the same TxClient take part in a single transaction.
+type TxClient struct {
+	client Client
+}
+
+func NewTxClient(client Client) *TxClient {
+	return &TxClient{client: client}
+}
+
+func (c *TxClient) conn(ctx context.Context) Client {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return c.client
+}
+
+func (c *TxClient) Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error) {
+	return c.conn(ctx).Exec(ctx, sql, arguments...)
+}
+
+func (c *TxClient) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return c.conn(ctx).Query(ctx, sql, args...)
+}
+
+func (c *TxClient) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return c.conn(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (c *TxClient) Begin(ctx context.Context) (pgx.Tx, error) {
+	return c.conn(ctx).Begin(ctx)
+}
+
+// WithinTransaction calls fn with a context carrying a transaction, which is committed
+// if fn succeeds and rolled back otherwise. If ctx already carries a transaction,
+// fn joins it and the outermost call decides on commit.
+func (c *TxClient) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := c.client.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// no-op if the transaction is already committed
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestIsPublic(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.0.0.1", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "224.0.0.1", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isPublic(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("isPublic(%s) = %t, want %t", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		sender  *Sender
+		url     string
+		wantErr error
+	}{
+		{name: "loopback", sender: NewPublicSender(time.Second), url: "http://127.0.0.1:8080/hook",
+			wantErr: ErrForbiddenAddress},
+		{name: "metadata address", sender: NewPublicSender(time.Second), url: "http://169.254.169.254/latest",
+			wantErr: ErrForbiddenAddress},
+		{name: "localhost", sender: NewPublicSender(time.Second), url: "http://localhost/hook",
+			wantErr: ErrForbiddenAddress},
+		{name: "public address", sender: NewPublicSender(time.Second), url: "https://93.184.216.34/hook"},
+		{name: "any address for operators", sender: NewSender(time.Second), url: "http://127.0.0.1/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sender.CheckURL(context.Background(), tt.url)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckURL(%q) error = %v, want %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	const secret = "secret"
+	payload := []byte(`{"event":"test"}`)
+
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timestamp, _ := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		verified = Verify(secret, timestamp, payload, r.Header.Get(SignatureHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	t.Run("public sender does not connect to loopback", func(t *testing.T) {
+		_, err := NewPublicSender(time.Second).Send(context.Background(), server.URL, secret, "test", "1", payload)
+		if !errors.Is(err, ErrForbiddenAddress) {
+			t.Fatalf("Send() error = %v, want %v", err, ErrForbiddenAddress)
+		}
+	})
+
+	t.Run("signed delivery", func(t *testing.T) {
+		status, err := NewSender(time.Second).Send(context.Background(), server.URL, secret, "test", "1", payload)
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+		if status != http.StatusNoContent {
+			t.Errorf("Send() status = %d, want %d", status, http.StatusNoContent)
+		}
+		if !verified {
+			t.Error("receiver could not verify the signature")
+		}
+	})
+}